@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"ingresso-finder-cli/model"
+)
+
+func runCities(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "cities")
+	uf := fs.String("uf", "", "only list cities from this state (e.g. SP)")
+	formatFlag := fs.String("format", string(formatTable), "output format: json, tsv or table")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument: %s", positional[0])
+	}
+	format, err := parseFormat(*formatFlag, formatJSON, formatTSV, formatTable)
+	if err != nil {
+		return err
+	}
+
+	cities, err := e.finder.Cities(ctx)
+	if err != nil {
+		return err
+	}
+	cities = filterCitiesByUF(cities, *uf)
+	sort.Slice(cities, func(i, j int) bool {
+		return strings.ToLower(cities[i].Name) < strings.ToLower(cities[j].Name)
+	})
+
+	t := table{header: []string{"id", "name", "uf", "state"}}
+	for _, city := range cities {
+		t.rows = append(t.rows, []string{city.Id, city.Name, city.Uf, city.State})
+	}
+	return writeOutput(e.stdout, format, cities, t)
+}
+
+func filterCitiesByUF(cities []model.City, uf string) []model.City {
+	uf = strings.TrimSpace(uf)
+	if uf == "" {
+		return cities
+	}
+	filtered := make([]model.City, 0, len(cities))
+	for _, city := range cities {
+		if strings.EqualFold(city.Uf, uf) {
+			filtered = append(filtered, city)
+		}
+	}
+	return filtered
+}
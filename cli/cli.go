@@ -0,0 +1,212 @@
+// Package cli implements the non-interactive subcommands of the ingresso binary.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"ingresso-finder-cli/finder"
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	// hidden commands are internal plumbing left out of the help output.
+	hidden bool
+	run    func(ctx context.Context, env *env, args []string) error
+}
+
+type env struct {
+	finder *finder.Finder
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// usageError marks errors caused by invalid command-line input.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+func commands() []command {
+	return []command{
+		{
+			name:    "cities",
+			usage:   "cities [--uf SP] [--format json|tsv|table]",
+			summary: "List the cities available on Ingresso",
+			run:     runCities,
+		},
+		{
+			name:    "theaters",
+			usage:   "theaters (--city NAME | --group NAME) [--near lat,lng | --locate] [--include-hidden] [--format json|tsv|table]",
+			summary: "List the theaters of a city or city group, optionally sorted by distance",
+			run:     runTheaters,
+		},
+		{
+			name:    "sessions",
+			usage:   "sessions --city NAME --theater ID|NAME [--date YYYY-MM-DD] [--movie TEXT] [--session ID] [--format json|csv|ics|table]",
+			summary: "List the sessions of a theater, one row per session",
+			run:     runSessions,
+		},
+		{
+			name:    "find",
+			usage:   "find TITLE (--city NAME | --group NAME) [--date YYYY-MM-DD] [--near lat,lng | --locate] [--include-hidden] [--format json|csv|table]",
+			summary: "Search a movie across every visible theater of a city or city group",
+			run:     runFind,
+		},
+		{
+			name:    "groups",
+			usage:   "groups [list | add NAME CITY... | rm NAME] [--format json|tsv|table]",
+			summary: "Manage named city groups searched together, like a metro area",
+			run:     runGroups,
+		},
+		{
+			name:    "seats",
+			usage:   "seats SESSION_ID [--section ID|NAME] [--no-color] [--numbers]",
+			summary: "Print the seat map of a session",
+			run:     runSeats,
+		},
+		{
+			name:    "watch",
+			usage:   "watch SESSION_ID (--adjacent N | --seat G12,G13) [--section ID|NAME] [--interval 30s]",
+			summary: "Poll a seat map until the wanted seats become available",
+			run:     runWatch,
+		},
+		{
+			name:    "serve",
+			usage:   "serve [--addr localhost:8080]",
+			summary: "Serve cities, theaters, sessions and seat maps as a local JSON API",
+			run:     runServe,
+		},
+		{
+			name:    "rpc",
+			usage:   "rpc",
+			summary: "Answer line-delimited JSON-RPC 2.0 requests on stdin/stdout",
+			run:     runRPC,
+		},
+		{
+			name:    "completion",
+			usage:   "completion bash|zsh|fish",
+			summary: "Print a shell completion script",
+			run:     runCompletion,
+		},
+		{
+			name:   completeCommand,
+			hidden: true,
+			run:    runComplete,
+		},
+	}
+}
+
+func lookup(name string) (command, bool) {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// IsCommand reports whether name is a known subcommand.
+func IsCommand(name string) bool {
+	_, ok := lookup(name)
+	return ok
+}
+
+// PrintCommands writes the list of subcommands to w.
+func PrintCommands(w io.Writer) {
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands() {
+		if cmd.hidden {
+			continue
+		}
+		fmt.Fprintf(w, "  %-12s %s\n", cmd.name, cmd.summary)
+		fmt.Fprintf(w, "  %-12s ingresso %s\n", "", cmd.usage)
+	}
+}
+
+// Run executes the subcommand named by args[0] and returns the process exit code.
+// The API client honors config.json and the INGRESSO_* environment overrides.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	client, err := finder.NewClient()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	e := &env{
+		finder: finder.New(client),
+		stdout: stdout,
+		stderr: stderr,
+	}
+	return run(ctx, e, args)
+}
+
+func run(ctx context.Context, e *env, args []string) int {
+	if len(args) == 0 {
+		PrintCommands(e.stderr)
+		return 2
+	}
+	cmd, ok := lookup(args[0])
+	if !ok {
+		fmt.Fprintf(e.stderr, "Unknown command: %s\n", args[0])
+		PrintCommands(e.stderr)
+		return 2
+	}
+
+	ctx, stale := finder.TrackStale(ctx)
+	err := cmd.run(ctx, e, args[1:])
+	if since := stale.Since(); !since.IsZero() {
+		fmt.Fprintf(e.stderr, "offline: using cached data from %s\n", since.Local().Format("2006-01-02 15:04"))
+	}
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprintf(e.stderr, "%s\n", usageErr.msg)
+		fmt.Fprintf(e.stderr, "Usage: ingresso %s\n", cmd.usage)
+		return 2
+	}
+	fmt.Fprintf(e.stderr, "%s: %s\n", cmd.name, err.Error())
+	return 1
+}
+
+func newFlagSet(e *env, cmd string) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+// parseArgs parses flags that may be interleaved with positional arguments
+// and returns the positional ones in order.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if len(args) > len(rest) && args[len(args)-len(rest)-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
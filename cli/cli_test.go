@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/internal/ingressotest"
+	"ingresso-finder-cli/model"
+)
+
+func newTestEnv(t *testing.T, handler http.Handler) (*env, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	ingressotest.IsolateStore(t)
+
+	client := ingressotest.NewClient(t, handler)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	return &env{finder: finder.New(client), stdout: stdout, stderr: stderr}, stdout, stderr
+}
+
+func citiesHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/states" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"name":"Sao Paulo","uf":"SP","cities":[{"id":"1","name":"Sao Paulo","uf":"SP","state":"Sao Paulo"},{"id":"2","name":"Campinas","uf":"SP","state":"Sao Paulo"}]},
+			{"name":"Rio de Janeiro","uf":"RJ","cities":[{"id":"3","name":"Rio de Janeiro","uf":"RJ","state":"Rio de Janeiro"}]}
+		]`))
+	})
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	e, _, stderr := newTestEnv(t, http.NotFoundHandler())
+
+	if code := run(context.Background(), e, []string{"nope"}); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Unknown command") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestCities_JSONFilteredByUF(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, citiesHandler(t))
+
+	if code := run(context.Background(), e, []string{"cities", "--uf", "sp", "--format", "json"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+
+	var cities []model.City
+	if err := json.Unmarshal(stdout.Bytes(), &cities); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(cities) != 2 {
+		t.Fatalf("expected 2 cities, got %d", len(cities))
+	}
+	if cities[0].Name != "Campinas" || cities[1].Name != "Sao Paulo" {
+		t.Fatalf("expected cities sorted by name, got %+v", cities)
+	}
+}
+
+func TestCities_TSV(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, citiesHandler(t))
+
+	if code := run(context.Background(), e, []string{"cities", "--format=tsv"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header plus 3 rows, got %q", stdout.String())
+	}
+	if lines[0] != "id\tname\tuf\tstate" {
+		t.Fatalf("unexpected header: %q", lines[0])
+	}
+	if lines[1] != "2\tCampinas\tSP\tSao Paulo" {
+		t.Fatalf("unexpected first row: %q", lines[1])
+	}
+}
+
+func TestCities_InvalidFormat(t *testing.T) {
+	e, _, stderr := newTestEnv(t, citiesHandler(t))
+
+	if code := run(context.Background(), e, []string{"cities", "--format", "xml"}); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "invalid --format") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestParseArgs_InterleavedPositionals(t *testing.T) {
+	e, _, _ := newTestEnv(t, http.NotFoundHandler())
+	fs := newFlagSet(e, "test")
+	city := fs.String("city", "", "")
+
+	positional, err := parseArgs(fs, []string{"Duna", "--city", "Sao Paulo", "extra"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if *city != "Sao Paulo" {
+		t.Fatalf("expected city flag to be parsed, got %q", *city)
+	}
+	if len(positional) != 2 || positional[0] != "Duna" || positional[1] != "extra" {
+		t.Fatalf("unexpected positional args: %v", positional)
+	}
+}
@@ -0,0 +1,304 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/store"
+)
+
+// completeCommand is the hidden command the completion scripts call back into.
+const completeCommand = "__complete"
+
+var usageFlagPattern = regexp.MustCompile(`--([a-z-]+)(?: ([^\s\]\)]+))?`)
+
+func runCompletion(_ context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "completion")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("expected exactly one shell: bash, zsh or fish")
+	}
+	script, ok := completionScripts[positional[0]]
+	if !ok {
+		return usageErrorf("unsupported shell %q (expected bash, zsh or fish)", positional[0])
+	}
+	_, err = fmt.Fprint(e.stdout, script)
+	return err
+}
+
+// runComplete prints one candidate per line for the last word of args, which
+// are the words typed after the program name. It only reads the local caches,
+// so completion keeps working offline.
+func runComplete(_ context.Context, e *env, args []string) error {
+	for _, candidate := range completeWords(args) {
+		fmt.Fprintln(e.stdout, candidate)
+	}
+	return nil
+}
+
+func completeWords(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	words, prefix := splitFlagWords(words)
+	words, ok := skipGlobalFlags(words)
+	if !ok {
+		return nil
+	}
+	candidates := completeSplitWords(words)
+	for i := range candidates {
+		candidates[i] = prefix + candidates[i]
+	}
+	return candidates
+}
+
+// splitFlagWords splits --flag=value words into the flag and its value, as the
+// flag package reads them, and drops the "=" that bash's COMP_WORDBREAKS turns
+// into a word of its own. When the last word was --flag=value it also returns
+// the --flag= its candidates must start with.
+func splitFlagWords(words []string) ([]string, string) {
+	split := make([]string, 0, len(words)+1)
+	prefix := ""
+	last := len(words) - 1
+	for i, word := range words {
+		if word == "=" && len(split) > 0 && strings.HasPrefix(split[len(split)-1], "--") {
+			if i == last {
+				split = append(split, "")
+			}
+			continue
+		}
+		if name, value, ok := strings.Cut(word, "="); ok && strings.HasPrefix(name, "--") {
+			split = append(split, name, value)
+			if i == last {
+				prefix = name + "="
+			}
+			continue
+		}
+		split = append(split, word)
+	}
+	return split, prefix
+}
+
+// skipGlobalFlags drops the global flags main.go reads before the command
+// (--offline, --record, --replay and --debug-log), so the command is the first
+// word left. It reports false when the word being completed is the path of
+// one of them, which only the shell can complete.
+func skipGlobalFlags(words []string) ([]string, bool) {
+	for len(words) > 1 {
+		switch words[0] {
+		case "--offline":
+			words = words[1:]
+		case "--record", "--replay", "--debug-log":
+			if len(words) == 2 {
+				return nil, false
+			}
+			words = words[2:]
+		default:
+			return words, true
+		}
+	}
+	return words, true
+}
+
+func completeSplitWords(words []string) []string {
+	current := unquoteWord(words[len(words)-1])
+	if len(words) == 1 {
+		var names []string
+		for _, cmd := range commands() {
+			if !cmd.hidden {
+				names = append(names, cmd.name)
+			}
+		}
+		return filterPrefix(names, current)
+	}
+
+	cmd, ok := lookup(words[0])
+	if !ok {
+		return nil
+	}
+	if cmd.name == "completion" {
+		if len(words) == 2 {
+			return filterPrefix([]string{"bash", "fish", "zsh"}, current)
+		}
+		return nil
+	}
+
+	flags := usageFlags(cmd.usage)
+	if previous := words[len(words)-2]; strings.HasPrefix(previous, "--") {
+		name := strings.TrimPrefix(previous, "--")
+		if values, ok := flags[name]; ok {
+			switch name {
+			case "city":
+				return filterPrefix(cachedCityNames(), current)
+			case "group":
+				return filterPrefix(cityGroupNames(), current)
+			case "theater":
+				return filterPrefix(cachedTheaterNames(flagValue(words, "city")), current)
+			}
+			return filterPrefix(values, current)
+		}
+	}
+	if strings.HasPrefix(current, "-") {
+		names := make([]string, 0, len(flags))
+		for name := range flags {
+			names = append(names, "--"+name)
+		}
+		sort.Strings(names)
+		return filterPrefix(names, current)
+	}
+	return nil
+}
+
+// usageFlags reads the flags of a command from its usage line. Flags whose
+// placeholder lists lowercase choices, like json|csv|table, get those values.
+func usageFlags(usage string) map[string][]string {
+	flags := map[string][]string{}
+	for _, match := range usageFlagPattern.FindAllStringSubmatch(usage, -1) {
+		var values []string
+		if placeholder := match[2]; strings.Contains(placeholder, "|") && placeholder == strings.ToLower(placeholder) {
+			values = strings.Split(placeholder, "|")
+		}
+		flags[match[1]] = values
+	}
+	return flags
+}
+
+func flagValue(words []string, name string) string {
+	for i := 0; i < len(words)-2; i++ {
+		if words[i] == "--"+name {
+			return unquoteWord(words[i+1])
+		}
+	}
+	return ""
+}
+
+// cityGroupNames lists the saved city groups.
+func cityGroupNames() []string {
+	groups, _ := store.LoadCityGroups()
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	return names
+}
+
+// cachedCityNames lists the recent cities first, then every cached city.
+func cachedCityNames() []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		key := finder.FoldName(name)
+		if name != "" && !seen[key] {
+			seen[key] = true
+			names = append(names, name)
+		}
+	}
+	recent, _ := store.LoadRecentCities()
+	for _, city := range recent {
+		add(city.Name)
+	}
+	cities, _, _ := store.LoadCityCache()
+	sort.Slice(cities, func(i, j int) bool { return finder.FoldName(cities[i].Name) < finder.FoldName(cities[j].Name) })
+	for _, city := range cities {
+		add(city.Name)
+	}
+	return names
+}
+
+func cachedTheaterNames(cityName string) []string {
+	cityID := cachedCityID(cityName)
+	if cityID == "" {
+		return nil
+	}
+	theaters, _, _ := store.LoadTheaterCache(cityID)
+	names := make([]string, 0, len(theaters))
+	for _, theater := range theaters {
+		names = append(names, theater.Name)
+	}
+	sort.Slice(names, func(i, j int) bool { return finder.FoldName(names[i]) < finder.FoldName(names[j]) })
+	return names
+}
+
+func cachedCityID(name string) string {
+	key := finder.FoldName(name)
+	if key == "" {
+		return ""
+	}
+	recent, _ := store.LoadRecentCities()
+	for _, city := range recent {
+		if city.ID != "" && finder.FoldName(city.Name) == key {
+			return city.ID
+		}
+	}
+	cities, _, _ := store.LoadCityCache()
+	for _, city := range cities {
+		if finder.FoldName(city.Name) == key {
+			return city.Id
+		}
+	}
+	return ""
+}
+
+// filterPrefix keeps the candidates starting with prefix, ignoring case and accents.
+func filterPrefix(candidates []string, prefix string) []string {
+	key := finder.FoldName(prefix)
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(finder.FoldName(candidate), key) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// unquoteWord undoes the quoting bash leaves in COMP_WORDS, so "Sao\ Pa,
+// 'Sao Pa and "Sao Pa" all become Sao Pa. A closing quote is only dropped
+// when it matches the opening one.
+func unquoteWord(word string) string {
+	if word != "" && (word[0] == '"' || word[0] == '\'') {
+		word = strings.TrimSuffix(word[1:], word[:1])
+	}
+	return strings.ReplaceAll(word, `\ `, " ")
+}
+
+var completionScripts = map[string]string{
+	"bash": `# bash completion for ingresso
+# Load with: source <(ingresso completion bash)
+_ingresso() {
+    local IFS=$'\n' candidate
+    local -a candidates
+    candidates=($(ingresso __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+    COMPREPLY=()
+    for candidate in "${candidates[@]}"; do
+        COMPREPLY+=("$(printf '%q' "$candidate")")
+    done
+}
+complete -o default -F _ingresso ingresso
+`,
+	"zsh": `#compdef ingresso
+# zsh completion for ingresso
+# Load with: source <(ingresso completion zsh)
+_ingresso() {
+    local -a candidates
+    candidates=("${(@f)$(ingresso __complete "${(@Q)words[2,CURRENT]}" 2>/dev/null)}")
+    candidates=(${candidates:#})
+    (( ${#candidates} )) && compadd -U -- "${candidates[@]}"
+}
+if [[ "$funcstack[1]" = "_ingresso" ]]; then
+    _ingresso "$@"
+else
+    compdef _ingresso ingresso
+fi
+`,
+	"fish": `# fish completion for ingresso
+# Load with: ingresso completion fish | source
+complete -c ingresso -f -a '(ingresso __complete (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)'
+`,
+}
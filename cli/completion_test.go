@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"ingresso-finder-cli/internal/ingressotest"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/store"
+)
+
+func seedCompletionCache(t *testing.T) {
+	t.Helper()
+	ingressotest.IsolateStore(t)
+	if err := store.SaveCityCache([]model.City{{Id: "1", Name: "São Paulo", Uf: "SP"}, {Id: "3", Name: "Santos", Uf: "SP"}, {Id: "2", Name: "Rio de Janeiro", Uf: "RJ"}}); err != nil {
+		t.Fatalf("save city cache: %v", err)
+	}
+	if err := store.RememberCity(model.City{Id: "3", Name: "Santos", Uf: "SP"}); err != nil {
+		t.Fatalf("remember city: %v", err)
+	}
+	if err := store.SaveTheaterCache("1", []model.Theater{{Id: "10", Name: "Reserva Cultural"}, {Id: "11", Name: "Cinemark Paulista"}}); err != nil {
+		t.Fatalf("save theater cache: %v", err)
+	}
+}
+
+func TestCompleteWords(t *testing.T) {
+	seedCompletionCache(t)
+
+	cases := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{"se"}, []string{"sessions", "seats", "serve"}},
+		{[]string{"sessions", "--city", "sa"}, []string{"Santos", "São Paulo"}},
+		{[]string{"sessions", "--city", `"Sao\ P`}, []string{"São Paulo"}},
+		{[]string{"sessions", "--city", "sao paulo", "--theater", ""}, []string{"Cinemark Paulista", "Reserva Cultural"}},
+		{[]string{"sessions", "--city", `"Sao Paulo"`, "--theater", "c"}, []string{"Cinemark Paulista"}},
+		{[]string{"sessions", "--city", `'Santos'`}, []string{"Santos"}},
+		{[]string{"sessions", "--city=sa"}, []string{"--city=Santos", "--city=São Paulo"}},
+		{[]string{"sessions", `--city="Sao`}, []string{"--city=São Paulo"}},
+		{[]string{"sessions", "--city", "=", "San"}, []string{"Santos"}},
+		{[]string{"sessions", "--city", "="}, []string{"Santos", "Rio de Janeiro", "São Paulo"}},
+		{[]string{"sessions", "--city=São Paulo", "--theater", "r"}, []string{"Reserva Cultural"}},
+		{[]string{"sessions", "--format", "i"}, []string{"ics"}},
+		{[]string{"cities", "--f"}, []string{"--format"}},
+		{[]string{"cities", "--city", ""}, nil},
+		{[]string{"completion", "z"}, []string{"zsh"}},
+		{[]string{"--offline", "sess"}, []string{"sessions"}},
+		{[]string{"--record", "fixtures", "--debug-log=ingresso.log", "se"}, []string{"sessions", "seats", "serve"}},
+		{[]string{"--offline", "sessions", "--city", "sa"}, []string{"Santos", "São Paulo"}},
+		{[]string{"--replay", ""}, nil},
+		{[]string{"--debug-log=ing"}, nil},
+	}
+	for _, tc := range cases {
+		got := completeWords(tc.words)
+		if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+			t.Errorf("completeWords(%q) = %q, want %q", tc.words, got, tc.want)
+		}
+	}
+}
+
+func TestComplete_IsHiddenFromHelp(t *testing.T) {
+	var out strings.Builder
+	PrintCommands(&out)
+	if strings.Contains(out.String(), completeCommand) || !strings.Contains(out.String(), "completion") {
+		t.Fatalf("unexpected help output:\n%s", out.String())
+	}
+}
+
+func TestCompletion_PrintsScript(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, citiesHandler(t))
+
+	if code := run(context.Background(), e, []string{"completion", "bash"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "ingresso __complete") {
+		t.Fatalf("unexpected script:\n%s", stdout.String())
+	}
+	if code := run(context.Background(), e, []string{"completion", "tcsh"}); code != 2 {
+		t.Fatalf("expected exit code 2 for an unknown shell, got %d", code)
+	}
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/finder"
+)
+
+type findResult struct {
+	Query        string       `json:"query"`
+	City         string       `json:"city"`
+	Date         string       `json:"date"`
+	Sessions     []sessionRow `json:"sessions"`
+	Theaters     int          `json:"theaters"`
+	Failed       int          `json:"failed"`
+	Ignored      int          `json:"ignored"`
+	FailedCities int          `json:"failedCities,omitempty"`
+}
+
+func runFind(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "find")
+	cityName := fs.String("city", "", "city name")
+	groupName := fs.String("group", "", "city group name, instead of --city")
+	dateFlag := fs.String("date", "", "session date as YYYY-MM-DD (default today)")
+	near := fs.String("near", "", "sort by distance to lat,lng")
+	locate := fs.Bool("locate", false, "sort by distance to the detected current location")
+	includeHidden := fs.Bool("include-hidden", false, "also search theaters hidden in the TUI")
+	formatFlag := fs.String("format", string(formatTable), "output format: json, csv or table")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || strings.TrimSpace(positional[0]) == "" {
+		return usageErrorf("expected exactly one movie title")
+	}
+	query := strings.TrimSpace(positional[0])
+	format, err := parseFormat(*formatFlag, formatJSON, formatCSV, formatTable)
+	if err != nil {
+		return err
+	}
+	if *near != "" && *locate {
+		return usageErrorf("--near and --locate are mutually exclusive")
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+	scope, err := resolveScope(ctx, e, *cityName, *groupName)
+	if err != nil {
+		return err
+	}
+	location, err := resolveLocation(ctx, *near, *locate)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := e.finder.CitiesCatalog(ctx, scope.group.Cities, date, location, *includeHidden)
+	if err != nil {
+		return err
+	}
+
+	result := findResult{
+		Query:        query,
+		City:         scope.group.Name,
+		Date:         date.Format(time.DateOnly),
+		Sessions:     catalogRows(catalog, query),
+		Failed:       catalog.Failed,
+		Ignored:      catalog.Ignored,
+		FailedCities: catalog.FailedCities,
+	}
+	showing := map[string]bool{}
+	for _, row := range result.Sessions {
+		showing[row.TheaterID] = true
+	}
+	result.Theaters = len(showing)
+
+	if err := writeOutput(e.stdout, format, result, findTable(result.Sessions, location != nil)); err != nil {
+		return err
+	}
+	if format != formatJSON {
+		fmt.Fprintf(e.stderr, "%d sessions in %d theaters • %d theaters failed • %d theaters without sessions\n",
+			len(result.Sessions), result.Theaters, result.Failed, result.Ignored)
+		if result.FailedCities > 0 {
+			fmt.Fprintf(e.stderr, "%d cities of %s failed\n", result.FailedCities, result.City)
+		}
+	}
+	if len(result.Sessions) == 0 {
+		return fmt.Errorf("no sessions matching %q on %s", query, result.Date)
+	}
+	return nil
+}
+
+// catalogRows lists the sessions of every movie matching query, nearest theaters first.
+func catalogRows(catalog finder.Catalog, query string) []sessionRow {
+	rows := []sessionRow{}
+	for _, movie := range catalog.Movies {
+		if !movieMatches(movie.Movie, query) {
+			continue
+		}
+		for _, entry := range movie.Sessions {
+			row := sessionRow{
+				SessionID:        entry.Session.Id,
+				TheaterID:        entry.Theater.Id,
+				Theater:          entry.Theater.Name,
+				MovieID:          movie.Movie.Id,
+				Movie:            movie.Movie.Title,
+				Room:             entry.Session.Room,
+				Time:             entry.Session.Date.LocalDate,
+				Types:            entry.Session.Type,
+				Price:            entry.Session.Price,
+				HasSeatSelection: entry.Session.HasSeatSelection,
+			}
+			if entry.HasDistance {
+				distance := entry.DistanceKM
+				row.DistanceKM = &distance
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func findTable(rows []sessionRow, withDistance bool) table {
+	t := table{header: []string{"movie", "theater"}}
+	if withDistance {
+		t.header = append(t.header, "distance_km")
+	}
+	t.header = append(t.header, "time", "room", "types", "price", "session_id")
+	for _, row := range rows {
+		cells := []string{row.Movie, row.Theater}
+		if withDistance {
+			if row.DistanceKM != nil {
+				cells = append(cells, fmt.Sprintf("%.1f", *row.DistanceKM))
+			} else {
+				cells = append(cells, "")
+			}
+		}
+		cells = append(cells,
+			row.Time.Format("2006-01-02 15:04"),
+			row.Room,
+			strings.Join(row.Types, ", "),
+			formatPrice(row.Price),
+			row.SessionID,
+		)
+		t.rows = append(t.rows, cells)
+	}
+	return t
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func findHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v0/states/city/name/Sao Paulo":
+			_, _ = w.Write([]byte(`{"id":"1","name":"Sao Paulo","uf":"SP"}`))
+		case "/v0/theaters/city/1":
+			_, _ = w.Write([]byte(`[
+				{"id":"10","name":"Far Cinema","geolocation":{"lat":-22.9068,"lng":-43.1729}},
+				{"id":"11","name":"Near Cinema","geolocation":{"lat":-23.5505,"lng":-46.6333}},
+				{"id":"12","name":"Broken Cinema"},
+				{"id":"13","name":"Empty Cinema"}
+			]`))
+		case "/v0/sessions/city/1/theater/10", "/v0/sessions/city/1/theater/11":
+			_, _ = w.Write([]byte(`[{"date":"2026-10-20","movies":[
+				{"id":"m1","title":"Duna: Parte 2","rooms":[{"name":"Sala 1","sessions":[
+					{"id":"s-` + r.URL.Path[len(r.URL.Path)-2:] + `","price":40,"date":{"localDate":"2026-10-20T21:00:00-03:00"}}
+				]}]},
+				{"id":"m2","title":"Anora","rooms":[{"name":"Sala 2","sessions":[{"id":"x","date":{"localDate":"2026-10-20T19:00:00-03:00"}}]}]}
+			]}]`))
+		case "/v0/events/city/1":
+			// Without the per-movie endpoint, find crawls every theater.
+			w.WriteHeader(http.StatusNotFound)
+		case "/v0/sessions/city/1/theater/12":
+			w.WriteHeader(http.StatusBadRequest)
+		case "/v0/sessions/city/1/theater/13":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+}
+
+func TestFind_JSONReportsSessionsAndCounts(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, findHandler(t))
+
+	args := []string{"find", "duna", "--city", "Sao Paulo", "--date", "2026-10-20", "--near", "-23.55,-46.63", "--format", "json"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+
+	var result findResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(result.Sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(result.Sessions))
+	}
+	if result.Sessions[0].Theater != "Near Cinema" || result.Sessions[0].DistanceKM == nil {
+		t.Fatalf("expected nearest theater first with distance, got %+v", result.Sessions[0])
+	}
+	if result.Theaters != 2 || result.Failed != 1 || result.Ignored != 1 {
+		t.Fatalf("unexpected counts: theaters=%d failed=%d ignored=%d", result.Theaters, result.Failed, result.Ignored)
+	}
+}
+
+func TestFind_NoMatchesExitsWithError(t *testing.T) {
+	e, _, stderr := newTestEnv(t, findHandler(t))
+
+	args := []string{"find", "Matrix", "--city", "Sao Paulo", "--date", "2026-10-20"}
+	if code := run(context.Background(), e, args); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "no sessions matching") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
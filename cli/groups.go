@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/store"
+)
+
+// cityScope is what a command searches: one city or a saved city group.
+type cityScope struct {
+	// group names the scope and lists its cities; a single city is a group of one.
+	group store.CityGroup
+	// cityID is empty for groups; theaters then carry their own city.
+	cityID string
+}
+
+func (s cityScope) isGroup() bool {
+	return s.cityID == ""
+}
+
+// resolveScope resolves --city or --group, which are mutually exclusive.
+func resolveScope(ctx context.Context, e *env, cityName, groupName string) (cityScope, error) {
+	cityName, groupName = strings.TrimSpace(cityName), strings.TrimSpace(groupName)
+	if cityName != "" && groupName != "" {
+		return cityScope{}, usageErrorf("--city and --group are mutually exclusive")
+	}
+	if groupName == "" {
+		if cityName == "" {
+			return cityScope{}, usageErrorf("--city or --group is required")
+		}
+		city, err := resolveCity(ctx, e, cityName)
+		if err != nil {
+			return cityScope{}, err
+		}
+		return cityScope{group: store.CityGroup{Name: city.Name, Cities: []model.City{city}}, cityID: city.Id}, nil
+	}
+	group, ok, err := store.FindCityGroup(groupName)
+	if err != nil {
+		return cityScope{}, err
+	}
+	if !ok {
+		return cityScope{}, fmt.Errorf("unknown city group %q (see ingresso groups)", groupName)
+	}
+	return cityScope{group: group}, nil
+}
+
+// theaters lists the theaters of every city in the scope. failed counts the
+// cities of a group whose theaters could not be listed.
+func (s cityScope) theaters(ctx context.Context, e *env) (theaters []model.Theater, failed int, err error) {
+	if s.isGroup() {
+		return e.finder.GroupTheaters(ctx, s.group.Cities)
+	}
+	theaters, err = e.finder.Theaters(ctx, s.cityID)
+	return theaters, 0, err
+}
+
+func runGroups(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "groups")
+	formatFlag := fs.String("format", string(formatTable), "output format: json, tsv or table")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	action := "list"
+	if len(positional) > 0 {
+		action, positional = positional[0], positional[1:]
+	}
+
+	switch action {
+	case "list":
+		if len(positional) > 0 {
+			return usageErrorf("unexpected argument: %s", positional[0])
+		}
+		format, err := parseFormat(*formatFlag, formatJSON, formatTSV, formatTable)
+		if err != nil {
+			return err
+		}
+		groups, err := store.LoadCityGroups()
+		if err != nil {
+			return err
+		}
+		if groups == nil {
+			groups = []store.CityGroup{}
+		}
+		t := table{header: []string{"name", "cities"}}
+		for _, group := range groups {
+			names := make([]string, 0, len(group.Cities))
+			for _, city := range group.Cities {
+				names = append(names, city.Name)
+			}
+			t.rows = append(t.rows, []string{group.Name, strings.Join(names, ", ")})
+		}
+		return writeOutput(e.stdout, format, groups, t)
+
+	case "add":
+		if len(positional) < 2 {
+			return usageErrorf("expected a group name and at least one city")
+		}
+		group := store.CityGroup{Name: positional[0]}
+		for _, name := range positional[1:] {
+			city, err := resolveCity(ctx, e, name)
+			if err != nil {
+				return err
+			}
+			group.Cities = append(group.Cities, city)
+		}
+		if err := store.SaveCityGroup(group); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stderr, "saved group %q with %d cities\n", group.Name, len(group.Cities))
+		return nil
+
+	case "rm":
+		if len(positional) != 1 {
+			return usageErrorf("expected exactly one group name")
+		}
+		deleted, err := store.DeleteCityGroup(positional[0])
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return fmt.Errorf("unknown city group %q", positional[0])
+		}
+		return nil
+	}
+	return usageErrorf("unknown action %q (expected list, add or rm)", action)
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+func groupsHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v0/states/city/name/Sao Paulo":
+			_, _ = w.Write([]byte(`{"id":"1","name":"Sao Paulo","uf":"SP"}`))
+		case "/v0/states/city/name/Guarulhos":
+			_, _ = w.Write([]byte(`{"id":"2","name":"Guarulhos","uf":"SP"}`))
+		case "/v0/theaters/city/1":
+			_, _ = w.Write([]byte(`[{"id":"10","name":"Cinema Paulista"},{"id":"11","name":"Cinema Oculto"}]`))
+		case "/v0/theaters/city/2":
+			_, _ = w.Write([]byte(`[{"id":"20","name":"Cinema Guarulhos"}]`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+}
+
+func TestGroups_AddThenListTheatersOfEveryCity(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, groupsHandler(t))
+
+	if code := run(context.Background(), e, []string{"groups", "add", "Grande SP", "Sao Paulo", "Guarulhos"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+	if err := store.SetTheaterHidden("1", "11", true); err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"theaters", "--group", "grande sp", "--format", "json"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+	var rows []theaterRow
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	cities := map[string]string{}
+	for _, row := range rows {
+		cities[row.Id] = row.CityId + " " + row.City
+	}
+	if len(rows) != 2 || cities["10"] != "1 Sao Paulo" || cities["20"] != "2 Guarulhos" {
+		t.Fatalf("expected the visible theaters of both cities with their city, got %+v", rows)
+	}
+}
+
+func TestGroups_AddOfflineFromTheCityCache(t *testing.T) {
+	e, _, stderr := newTestEnv(t, http.NotFoundHandler())
+	e.finder = finder.New(service.NewClient(&http.Client{Transport: service.OfflineTransport()}))
+	if err := store.SaveCityCache([]model.City{{Id: "1", Name: "São Paulo", Uf: "SP"}, {Id: "2", Name: "Guarulhos", Uf: "SP"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := run(context.Background(), e, []string{"groups", "add", "Grande SP", "Sao Paulo", "Guarulhos"}); code != 0 {
+		t.Fatalf("expected exit code 0 offline, got %d (%s)", code, stderr.String())
+	}
+	group, ok, err := store.FindCityGroup("Grande SP")
+	if err != nil || !ok {
+		t.Fatalf("expected the group to be saved, got %v %v", ok, err)
+	}
+	if ids := group.CityIDs(); len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+		t.Fatalf("expected both cached cities, got %v", ids)
+	}
+}
+
+func TestGroups_TheatersSkipACityThatFails(t *testing.T) {
+	handler := groupsHandler(t)
+	e, stdout, stderr := newTestEnv(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v0/theaters/city/2" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	group := store.CityGroup{Name: "Grande SP", Cities: []model.City{{Id: "1", Name: "Sao Paulo"}, {Id: "2", Name: "Guarulhos"}}}
+	if err := store.SaveCityGroup(group); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := run(context.Background(), e, []string{"theaters", "--group", "Grande SP", "--format", "json"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+	var rows []theaterRow
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(rows) != 2 || rows[0].CityId != "1" {
+		t.Fatalf("expected the theaters of the city that loaded, got %+v", rows)
+	}
+	if !strings.Contains(stderr.String(), "1 cities of Grande SP failed") {
+		t.Fatalf("expected the failed city to be reported, got %q", stderr.String())
+	}
+}
+
+func TestGroups_CityAndGroupAreExclusive(t *testing.T) {
+	e, _, stderr := newTestEnv(t, groupsHandler(t))
+
+	if code := run(context.Background(), e, []string{"theaters", "--city", "Sao Paulo", "--group", "Grande SP"}); code != 2 {
+		t.Fatalf("expected exit code 2, got %d (%s)", code, stderr.String())
+	}
+}
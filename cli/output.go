@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+type outputFormat string
+
+const (
+	formatTable outputFormat = "table"
+	formatJSON  outputFormat = "json"
+	formatTSV   outputFormat = "tsv"
+	formatCSV   outputFormat = "csv"
+	formatICS   outputFormat = "ics"
+)
+
+// table is the tabular view of a command result used by the text formats.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func parseFormat(value string, allowed ...outputFormat) (outputFormat, error) {
+	normalized := outputFormat(strings.ToLower(strings.TrimSpace(value)))
+	names := make([]string, 0, len(allowed))
+	for _, format := range allowed {
+		if normalized == format {
+			return format, nil
+		}
+		names = append(names, string(format))
+	}
+	return "", usageErrorf("invalid --format %q (expected %s)", value, strings.Join(names, ", "))
+}
+
+// writeOutput renders value as JSON, or t in one of the text formats.
+func writeOutput(w io.Writer, format outputFormat, value any, t table) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(value)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(t.header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(t.rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case formatTSV:
+		if err := writeTSVRow(w, t.header); err != nil {
+			return err
+		}
+		for _, row := range t.rows {
+			if err := writeTSVRow(w, row); err != nil {
+				return err
+			}
+		}
+		return nil
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(upperAll(t.header), "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(sanitizeCells(row), "\t"))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+func writeTSVRow(w io.Writer, row []string) error {
+	_, err := fmt.Fprintln(w, strings.Join(sanitizeCells(row), "\t"))
+	return err
+}
+
+// sanitizeCells keeps every cell on a single line without embedded tabs.
+func sanitizeCells(row []string) []string {
+	cleaned := make([]string, len(row))
+	for i, cell := range row {
+		cleaned[i] = strings.Join(strings.Fields(cell), " ")
+	}
+	return cleaned
+}
+
+func upperAll(values []string) []string {
+	upper := make([]string, len(values))
+	for i, value := range values {
+		upper[i] = strings.ToUpper(value)
+	}
+	return upper
+}
+
+// isTerminal reports whether w is an interactive terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"ingresso-finder-cli/rpc"
+)
+
+// rpcInput is where the rpc command reads requests from; tests replace it.
+var rpcInput io.Reader = os.Stdin
+
+func runRPC(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "rpc")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument: %s", positional[0])
+	}
+
+	// Reading stdin blocks, so an interrupt has to end the command from here.
+	done := make(chan error, 1)
+	go func() {
+		done <- rpc.New(e.finder).Serve(ctx, rpcInput, e.stdout)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
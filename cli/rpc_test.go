@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRPC_AnswersFromStdin(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, citiesHandler(t))
+	rpcInput = strings.NewReader(`{"jsonrpc":"2.0","id":7,"method":"getCities"}` + "\n")
+	t.Cleanup(func() { rpcInput = os.Stdin })
+
+	if code := run(context.Background(), e, []string{"rpc"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.HasPrefix(out, `{"jsonrpc":"2.0","id":7,"result":[`) || strings.Count(out, "\n") != 1 {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/render"
+)
+
+func runSeats(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "seats")
+	sectionQuery := fs.String("section", "", "only render this section (id or name)")
+	noColor := fs.Bool("no-color", false, "disable colors")
+	numbers := fs.Bool("numbers", false, "show seat numbers instead of seat tokens")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || strings.TrimSpace(positional[0]) == "" {
+		return usageErrorf("expected exactly one session id")
+	}
+	sessionID := strings.TrimSpace(positional[0])
+
+	client := e.finder.Client()
+	detail, err := client.GetSessionDetails(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	sections, err := pickSections(finder.SeatSections(detail.Sections), *sectionQuery)
+	if err != nil {
+		return err
+	}
+
+	tty := isTerminal(e.stdout)
+	opts := render.SeatMapOptions{
+		ShowNumbers: *numbers,
+		NoColor:     *noColor || !tty,
+		ASCII:       !tty,
+	}
+	for i, section := range sections {
+		seatMap, err := client.GetSeatMap(ctx, sessionID, section.Id)
+		if err != nil {
+			return fmt.Errorf("load seat map for section %s: %w", section.Name, err)
+		}
+		if i > 0 {
+			fmt.Fprintln(e.stdout)
+		}
+		if len(sections) > 1 {
+			fmt.Fprintf(e.stdout, "== %s ==\n\n", section.Name)
+		}
+		fmt.Fprintln(e.stdout, render.SeatMap(seatMap, opts))
+	}
+	return nil
+}
+
+func pickSections(sections []model.SessionSection, query string) ([]model.SessionSection, error) {
+	if len(sections) == 0 {
+		return nil, errors.New("no seat map available for this session")
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return sections, nil
+	}
+	for _, section := range sections {
+		if section.Id == query || strings.EqualFold(section.Name, query) {
+			return []model.SessionSection{section}, nil
+		}
+	}
+	names := make([]string, 0, len(sections))
+	for _, section := range sections {
+		names = append(names, fmt.Sprintf("%s (%s)", section.Name, section.Id))
+	}
+	return nil, fmt.Errorf("section %q not found (available: %s)", query, strings.Join(names, ", "))
+}
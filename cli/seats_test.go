@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func seatsHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/sessions/s1":
+			_, _ = w.Write([]byte(`{"id":"s1","sections":[
+				{"id":"sec1","name":"Normal","hasSeatSelection":true},
+				{"id":"sec2","name":"VIP","hasSeatSelection":true},
+				{"id":"sec3","name":"Livre","hasSeatSelection":false}
+			]}`))
+		case "/v1/sessions/s1/sections/sec1/seats", "/v1/sessions/s1/sections/sec2/seats":
+			_, _ = w.Write([]byte(`{"id":"map","bounds":{"lines":2,"columns":3},"lines":[
+				{"line":1,"seats":[
+					{"id":"a1","label":"A 1","status":"Available","line":1,"column":1},
+					{"id":"a2","label":"A 2","status":"Available","line":1,"column":2},
+					{"id":"a3","label":"A 3","status":"Occupied","line":1,"column":3}
+				]},
+				{"line":2,"seats":[
+					{"id":"b1","label":"B 1","status":"Blocked","line":2,"column":1},
+					{"id":"b2","label":"B 2","status":"Available","type":"Disability","line":2,"column":2}
+				]}
+			]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+}
+
+func TestSeats_PlainASCIIWhenNotATerminal(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, seatsHandler(t))
+
+	if code := run(context.Background(), e, []string{"seats", "s1", "--section", "vip"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, r := range out {
+		if r > 127 {
+			t.Fatalf("expected ASCII-only output, found %q in:\n%s", r, out)
+		}
+	}
+	for _, want := range []string{"[]", "XX", "##", "DD", "SCREEN", "Disponiveis: 3"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "== ") {
+		t.Fatalf("expected no section headers for a single section:\n%s", out)
+	}
+}
+
+func TestSeats_RendersEverySeatSection(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, seatsHandler(t))
+
+	if code := run(context.Background(), e, []string{"seats", "s1", "--numbers"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "== Normal ==") || !strings.Contains(out, "== VIP ==") {
+		t.Fatalf("expected both seat sections, got:\n%s", out)
+	}
+	if strings.Contains(out, "== Livre ==") {
+		t.Fatalf("expected sections without seat selection to be skipped:\n%s", out)
+	}
+}
+
+func TestSeats_UnknownSection(t *testing.T) {
+	e, _, stderr := newTestEnv(t, seatsHandler(t))
+
+	if code := run(context.Background(), e, []string{"seats", "s1", "--section", "imax"}); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "not found") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"ingresso-finder-cli/server"
+)
+
+const serveShutdownTimeout = 5 * time.Second
+
+func runServe(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "serve")
+	addr := fs.String("addr", "localhost:8080", "address to listen on (use :8080 to accept remote clients)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument: %s", positional[0])
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	return serve(ctx, e, listener)
+}
+
+// serve answers API requests on listener until ctx is canceled.
+func serve(ctx context.Context, e *env, listener net.Listener) error {
+	srv := &http.Server{
+		Handler:           server.New(e.finder),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Fprintf(e.stderr, "Serving the Ingresso API on http://%s/api\n", listener.Addr())
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServe_AnswersUntilCanceled(t *testing.T) {
+	e, _, stderr := newTestEnv(t, citiesHandler(t))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- serve(ctx, e, listener) }()
+
+	resp, err := http.Get("http://" + listener.Addr().String() + "/api/cities")
+	if err != nil {
+		t.Fatalf("GET /api/cities: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("expected a clean shutdown, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), "/api") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
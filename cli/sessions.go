@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/ics"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+)
+
+type sessionRow struct {
+	SessionID        string    `json:"sessionId"`
+	TheaterID        string    `json:"theaterId"`
+	Theater          string    `json:"theater"`
+	DistanceKM       *float64  `json:"distanceKm,omitempty"`
+	MovieID          string    `json:"movieId"`
+	Movie            string    `json:"movie"`
+	Room             string    `json:"room"`
+	Time             time.Time `json:"time"`
+	Types            []string  `json:"types"`
+	Price            float64   `json:"price"`
+	HasSeatSelection bool      `json:"hasSeatSelection"`
+}
+
+func runSessions(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "sessions")
+	cityName := fs.String("city", "", "city name (required)")
+	theaterQuery := fs.String("theater", "", "theater id or name (required)")
+	dateFlag := fs.String("date", "", "session date as YYYY-MM-DD (default today)")
+	movieQuery := fs.String("movie", "", "only list movies whose title contains this text")
+	sessionID := fs.String("session", "", "only list the session with this id")
+	formatFlag := fs.String("format", string(formatTable), "output format: json, csv, ics or table")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument: %s", positional[0])
+	}
+	format, err := parseFormat(*formatFlag, formatJSON, formatCSV, formatICS, formatTable)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(*theaterQuery) == "" {
+		return usageErrorf("--theater is required")
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+	city, err := resolveCity(ctx, e, *cityName)
+	if err != nil {
+		return err
+	}
+	theaters, err := e.finder.Theaters(ctx, city.Id)
+	if err != nil {
+		return err
+	}
+	theater, err := finder.FindTheater(theaters, *theaterQuery)
+	if err != nil {
+		return err
+	}
+
+	days, err := e.finder.Sessions(ctx, city.Id, theater.Id, date)
+	if err != nil && !service.IsNotFound(err) {
+		return err
+	}
+	day := finder.SelectDay(days, date)
+	id := strings.TrimSpace(*sessionID)
+	if format == formatICS {
+		events := sessionEvents(theater, day, *movieQuery, id)
+		if len(events) == 0 {
+			return errors.New("no sessions to export")
+		}
+		return ics.Write(e.stdout, events)
+	}
+	rows := flattenSessions(theater, day, *movieQuery, id)
+	return writeOutput(e.stdout, format, rows, sessionTable(rows))
+}
+
+// flattenSessions turns a theater schedule into one row per session, ordered by time.
+// An empty sessionID keeps every session.
+func flattenSessions(theater model.Theater, day model.TheaterSessionDay, movieQuery, sessionID string) []sessionRow {
+	rows := []sessionRow{}
+	for _, movie := range day.Movies {
+		if !movieMatches(movie, movieQuery) {
+			continue
+		}
+		for _, session := range finder.MovieSessions(movie) {
+			if sessionID != "" && session.Id != sessionID {
+				continue
+			}
+			rows = append(rows, sessionRow{
+				SessionID:        session.Id,
+				TheaterID:        theater.Id,
+				Theater:          theater.Name,
+				MovieID:          movie.Id,
+				Movie:            movie.Title,
+				Room:             session.Room,
+				Time:             session.Date.LocalDate,
+				Types:            session.Type,
+				Price:            session.Price,
+				HasSeatSelection: session.HasSeatSelection,
+			})
+		}
+	}
+	sortSessionRows(rows)
+	return rows
+}
+
+// sessionEvents builds one calendar event per session, ordered by start time.
+func sessionEvents(theater model.Theater, day model.TheaterSessionDay, movieQuery, sessionID string) []ics.Event {
+	var events []ics.Event
+	for _, movie := range day.Movies {
+		if !movieMatches(movie, movieQuery) {
+			continue
+		}
+		for _, session := range finder.MovieSessions(movie) {
+			if sessionID != "" && session.Id != sessionID {
+				continue
+			}
+			events = append(events, finder.SessionEvent(movie, session, theater))
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events
+}
+
+func sortSessionRows(rows []sessionRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !rows[i].Time.Equal(rows[j].Time) {
+			return rows[i].Time.Before(rows[j].Time)
+		}
+		return strings.ToLower(rows[i].Movie) < strings.ToLower(rows[j].Movie)
+	})
+}
+
+func sessionTable(rows []sessionRow) table {
+	t := table{header: []string{"time", "movie", "room", "types", "price", "session_id"}}
+	for _, row := range rows {
+		t.rows = append(t.rows, []string{
+			row.Time.Format("2006-01-02 15:04"),
+			row.Movie,
+			row.Room,
+			strings.Join(row.Types, ", "),
+			formatPrice(row.Price),
+			row.SessionID,
+		})
+	}
+	return t
+}
+
+func movieMatches(movie model.TheaterMovie, query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(movie.Title), query) ||
+		strings.Contains(strings.ToLower(movie.OriginalTitle), query)
+}
+
+func parseDate(value string) (time.Time, error) {
+	date, err := finder.ParseDate(value)
+	if err != nil {
+		return time.Time{}, usageErrorf("invalid --date %q (%v)", strings.TrimSpace(value), err)
+	}
+	return date, nil
+}
+
+func formatPrice(price float64) string {
+	if price <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", price)
+}
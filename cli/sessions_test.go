@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func sessionsHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v0/states/city/name/Sao Paulo":
+			_, _ = w.Write([]byte(`{"id":"1","name":"Sao Paulo","uf":"SP"}`))
+		case "/v0/theaters/city/1":
+			_, _ = w.Write([]byte(`[{"id":"10","name":"Cinemark Paulista","address":"Av. Paulista, 2073"},{"id":"11","name":"Reserva Cultural"}]`))
+		case "/v0/sessions/city/1/theater/10":
+			if got := r.URL.Query().Get("date"); got != "2026-10-20" {
+				t.Fatalf("expected date 2026-10-20, got %q", got)
+			}
+			_, _ = w.Write([]byte(`[{"date":"2026-10-20","movies":[
+				{"id":"m1","title":"Duna","duration":"155","rooms":[{"name":"Sala 1","sessions":[
+					{"id":"s2","price":40,"type":["Legendado"],"hasSeatSelection":true,"date":{"localDate":"2026-10-20T21:00:00-03:00"}},
+					{"id":"s1","price":30,"type":["Dublado","3D"],"hasSeatSelection":true,"date":{"localDate":"2026-10-20T18:30:00-03:00"}}
+				]}]},
+				{"id":"m2","title":"Anora","rooms":[{"name":"Sala 2","sessions":[
+					{"id":"s3","price":35,"type":["Legendado"],"date":{"localDate":"2026-10-20T19:00:00-03:00"}}
+				]}]}
+			]}]`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+}
+
+func TestSessions_JSONFlattensRooms(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, sessionsHandler(t))
+
+	args := []string{"sessions", "--city", "Sao Paulo", "--theater", "paulista", "--date", "2026-10-20", "--format", "json"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+
+	var rows []sessionRow
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 sessions, got %d", len(rows))
+	}
+	got := []string{rows[0].SessionID, rows[1].SessionID, rows[2].SessionID}
+	if strings.Join(got, ",") != "s1,s3,s2" {
+		t.Fatalf("expected sessions ordered by time, got %v", got)
+	}
+	if rows[0].Room != "Sala 1" || rows[0].Theater != "Cinemark Paulista" {
+		t.Fatalf("expected room and theater to be filled, got %+v", rows[0])
+	}
+}
+
+func TestSessions_CSVWithMovieFilter(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, sessionsHandler(t))
+
+	args := []string{"sessions", "--city", "Sao Paulo", "--theater", "10", "--date", "2026-10-20", "--movie", "duna", "--format", "csv"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+
+	records, err := csv.NewReader(strings.NewReader(stdout.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header plus 2 rows, got %d", len(records))
+	}
+	if records[1][3] != "Dublado, 3D" {
+		t.Fatalf("unexpected types column: %q", records[1][3])
+	}
+}
+
+func TestSessions_RequiresTheater(t *testing.T) {
+	e, _, stderr := newTestEnv(t, sessionsHandler(t))
+
+	if code := run(context.Background(), e, []string{"sessions", "--city", "Sao Paulo"}); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "--theater is required") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestSessions_ICSForOneSession(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, sessionsHandler(t))
+
+	args := []string{"sessions", "--city", "Sao Paulo", "--theater", "10", "--date", "2026-10-20", "--session", "s1", "--format", "ics"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+
+	out := stdout.String()
+	if strings.Count(out, "BEGIN:VEVENT") != 1 {
+		t.Fatalf("expected exactly one event, got:\n%s", out)
+	}
+	for _, want := range []string{
+		"UID:s1@ingresso-finder-cli",
+		"DTSTART:20261020T213000Z",
+		"DTEND:20261021T000500Z",
+		"LOCATION:Cinemark Paulista\\, Av. Paulista\\, 2073",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
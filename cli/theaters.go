@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+type theaterRow struct {
+	model.Theater
+	DistanceKM *float64 `json:"distanceKm,omitempty"`
+	Hidden     bool     `json:"hidden,omitempty"`
+}
+
+func runTheaters(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "theaters")
+	cityName := fs.String("city", "", "city name")
+	groupName := fs.String("group", "", "city group name, instead of --city")
+	near := fs.String("near", "", "sort by distance to lat,lng")
+	locate := fs.Bool("locate", false, "sort by distance to the detected current location")
+	includeHidden := fs.Bool("include-hidden", false, "also list theaters hidden in the TUI")
+	formatFlag := fs.String("format", string(formatTable), "output format: json, tsv or table")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument: %s", positional[0])
+	}
+	format, err := parseFormat(*formatFlag, formatJSON, formatTSV, formatTable)
+	if err != nil {
+		return err
+	}
+	if *near != "" && *locate {
+		return usageErrorf("--near and --locate are mutually exclusive")
+	}
+	scope, err := resolveScope(ctx, e, *cityName, *groupName)
+	if err != nil {
+		return err
+	}
+	location, err := resolveLocation(ctx, *near, *locate)
+	if err != nil {
+		return err
+	}
+
+	theaters, failedCities, err := scope.theaters(ctx, e)
+	if err != nil {
+		return err
+	}
+	if failedCities > 0 {
+		fmt.Fprintf(e.stderr, "%d cities of %s failed\n", failedCities, scope.group.Name)
+	}
+	hidden, err := store.LoadHiddenTheaters(scope.group.CityIDs()...)
+	if err != nil {
+		return err
+	}
+	if !*includeHidden {
+		theaters = finder.VisibleTheaters(theaters, hidden)
+	}
+	finder.SortTheatersByDistance(theaters, location)
+
+	rows := make([]theaterRow, 0, len(theaters))
+	t := table{header: []string{"id", "name", "neighborhood", "address"}}
+	if scope.isGroup() {
+		t.header = append(t.header, "city")
+	}
+	if location != nil {
+		t.header = append(t.header, "distance_km")
+	}
+	if *includeHidden {
+		t.header = append(t.header, "hidden")
+	}
+	for _, theater := range theaters {
+		row := theaterRow{Theater: theater, Hidden: hidden[theater.Id]}
+		cells := []string{theater.Id, theater.Name, theater.Neighborhood, theater.Address}
+		if scope.isGroup() {
+			cells = append(cells, theater.City)
+		}
+		if location != nil {
+			distance, ok := finder.TheaterDistanceKM(theater, location)
+			if ok {
+				row.DistanceKM = &distance
+				cells = append(cells, fmt.Sprintf("%.1f", distance))
+			} else {
+				cells = append(cells, "")
+			}
+		}
+		if *includeHidden {
+			cells = append(cells, strconv.FormatBool(row.Hidden))
+		}
+		rows = append(rows, row)
+		t.rows = append(t.rows, cells)
+	}
+	return writeOutput(e.stdout, format, rows, t)
+}
+
+func resolveCity(ctx context.Context, e *env, name string) (model.City, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.City{}, usageErrorf("--city is required")
+	}
+	city, err := e.finder.CityByName(ctx, name)
+	if err != nil {
+		return model.City{}, fmt.Errorf("resolve city %q: %w", name, err)
+	}
+	return city, nil
+}
+
+// resolveLocation returns the reference point for distance sorting, or nil when none was requested.
+func resolveLocation(ctx context.Context, near string, locate bool) (*service.UserLocation, error) {
+	if near != "" {
+		return parseNear(near)
+	}
+	if !locate {
+		return nil, nil
+	}
+	location, err := service.DetectCurrentLocation(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect current location: %w", err)
+	}
+	return &location, nil
+}
+
+func parseNear(value string) (*service.UserLocation, error) {
+	location, err := finder.ParseNear(value)
+	if err != nil {
+		return nil, usageErrorf("invalid --near %q (%v)", value, err)
+	}
+	return location, nil
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+func theatersHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v0/states/city/name/Sao Paulo":
+			_, _ = w.Write([]byte(`{"id":"1","name":"Sao Paulo","uf":"SP"}`))
+		case "/v0/theaters/city/1":
+			_, _ = w.Write([]byte(`[
+				{"id":"10","name":"Far Cinema","geolocation":{"lat":-22.9068,"lng":-43.1729}},
+				{"id":"11","name":"Near Cinema","geolocation":{"lat":-23.5505,"lng":-46.6333}},
+				{"id":"12","name":"Hidden Cinema","geolocation":{"lat":-23.5506,"lng":-46.6334}}
+			]`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+}
+
+func TestTheaters_SortsByDistanceAndSkipsHidden(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, theatersHandler(t))
+	if err := store.SetTheaterHidden("1", "12", true); err != nil {
+		t.Fatalf("hide theater: %v", err)
+	}
+
+	args := []string{"theaters", "--city", "Sao Paulo", "--near", "-23.55,-46.63", "--format", "json"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+
+	var rows []theaterRow
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 visible theaters, got %d", len(rows))
+	}
+	if rows[0].Id != "11" || rows[1].Id != "10" {
+		t.Fatalf("expected near theater first, got %s then %s", rows[0].Id, rows[1].Id)
+	}
+	if rows[0].DistanceKM == nil {
+		t.Fatal("expected distance to be reported")
+	}
+}
+
+func TestTheaters_IncludeHidden(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, theatersHandler(t))
+	if err := store.SetTheaterHidden("1", "12", true); err != nil {
+		t.Fatalf("hide theater: %v", err)
+	}
+
+	args := []string{"theaters", "--city", "Sao Paulo", "--include-hidden", "--format", "json"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+
+	var rows []theaterRow
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 theaters, got %d", len(rows))
+	}
+	hidden := 0
+	for _, row := range rows {
+		if row.Hidden {
+			hidden++
+		}
+	}
+	if hidden != 1 {
+		t.Fatalf("expected exactly one hidden theater, got %d", hidden)
+	}
+}
+
+func TestTheaters_OfflineResolvesCityFromCache(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, http.NotFoundHandler())
+	e.finder = finder.New(service.NewClient(&http.Client{Transport: service.OfflineTransport()}))
+	if err := store.SaveCityCache([]model.City{{Id: "1", Name: "São Paulo", Uf: "SP"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveTheaterCache("1", []model.Theater{{Id: "10", Name: "Cinema A"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"theaters", "--city", "Sao Paulo", "--format", "json"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0 offline, got %d (%s)", code, stderr.String())
+	}
+	var rows []theaterRow
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Id != "10" {
+		t.Fatalf("expected the cached theater, got %+v", rows)
+	}
+}
+
+func TestParseNear_Invalid(t *testing.T) {
+	if _, err := parseNear("abc"); err == nil {
+		t.Fatal("expected error for malformed --near")
+	}
+	if _, err := parseNear("-123,10"); err == nil {
+		t.Fatal("expected error for out of range latitude")
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+)
+
+// minWatchInterval keeps the poller from hammering the checkout API.
+var minWatchInterval = 5 * time.Second
+
+func runWatch(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "watch")
+	adjacent := fs.Int("adjacent", 0, "alert when this many adjacent seats are available")
+	seats := fs.String("seat", "", "comma separated seat labels to wait for, e.g. G12,G13")
+	sectionQuery := fs.String("section", "", "only watch this section (id or name)")
+	interval := fs.Duration("interval", 30*time.Second, "time between checks")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || strings.TrimSpace(positional[0]) == "" {
+		return usageErrorf("expected exactly one session id")
+	}
+	sessionID := strings.TrimSpace(positional[0])
+	if *adjacent < 0 {
+		return usageErrorf("--adjacent must be positive")
+	}
+	if *interval < minWatchInterval {
+		return usageErrorf("--interval must be at least %s", minWatchInterval)
+	}
+	watch := finder.SeatWatch{Adjacent: *adjacent, Labels: splitList(*seats)}
+	if !watch.Enabled() {
+		return usageErrorf("--adjacent or --seat is required")
+	}
+
+	client := e.finder.Client()
+	detail, err := client.GetSessionDetails(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	sections, err := pickSections(finder.SeatSections(detail.Sections), *sectionQuery)
+	if err != nil {
+		return err
+	}
+
+	bell := isTerminal(e.stdout)
+	for {
+		alerts, available, err := checkWatch(ctx, client, sessionID, sections, watch)
+		now := time.Now().Format(time.TimeOnly)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return errors.New("watch interrupted")
+			}
+			fmt.Fprintf(e.stderr, "%s check failed: %v\n", now, err)
+		case len(alerts) > 0:
+			for _, line := range alerts {
+				fmt.Fprintf(e.stdout, "%s %s\n", now, line)
+			}
+			if bell {
+				fmt.Fprint(e.stdout, "\a")
+			}
+			return nil
+		default:
+			fmt.Fprintf(e.stderr, "%s no match yet (%d seats available)\n", now, available)
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.New("watch interrupted")
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// checkWatch loads every section once and describes what matched the watch.
+// Seat maps bypass the client's response cache so every poll sees the latest state.
+func checkWatch(ctx context.Context, client *service.Client, sessionID string, sections []model.SessionSection, watch finder.SeatWatch) ([]string, int, error) {
+	ctx = service.WithoutResponseCache(ctx)
+	var lines []string
+	available := 0
+	for _, section := range sections {
+		seatMap, err := client.GetSeatMap(ctx, sessionID, section.Id)
+		if err != nil {
+			return nil, 0, fmt.Errorf("load seat map for section %s: %w", section.Name, err)
+		}
+		available += finder.CountSeats(seatMap).Available
+		alert := watch.Check(seatMap)
+		prefix := ""
+		if len(sections) > 1 {
+			prefix = section.Name + ": "
+		}
+		for _, block := range alert.Blocks {
+			lines = append(lines, fmt.Sprintf("%s%d adjacent seats available at %s", prefix, len(block), finder.BlockLabel(block)))
+		}
+		for _, label := range alert.Labels {
+			lines = append(lines, fmt.Sprintf("%sseat %s is available", prefix, label))
+		}
+	}
+	return lines, available, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
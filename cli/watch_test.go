@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatch_AlertsWhenBlockFrees(t *testing.T) {
+	minWatchInterval = time.Millisecond
+	t.Cleanup(func() { minWatchInterval = 5 * time.Second })
+
+	var polls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/sessions/s1":
+			_, _ = w.Write([]byte(`{"id":"s1","sections":[{"id":"sec1","name":"Normal","hasSeatSelection":true}]}`))
+		case "/v1/sessions/s1/sections/sec1/seats":
+			status := "Occupied"
+			if polls.Add(1) >= 2 {
+				status = "Available"
+			}
+			_, _ = w.Write([]byte(`{"bounds":{"lines":1,"columns":3},"lines":[{"line":1,"seats":[
+				{"label":"G 11","status":"Available","line":1,"column":1},
+				{"label":"G 12","status":"` + status + `","line":1,"column":2},
+				{"label":"G 13","status":"Available","line":1,"column":3}
+			]}]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	e, stdout, stderr := newTestEnv(t, handler)
+
+	args := []string{"watch", "s1", "--adjacent", "3", "--interval", "1ms"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+	if polls.Load() != 2 {
+		t.Fatalf("expected 2 polls, got %d", polls.Load())
+	}
+	if !strings.Contains(stdout.String(), "3 adjacent seats available at G11-G13") {
+		t.Fatalf("unexpected stdout: %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "no match yet (2 seats available)") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestWatch_SeatLabel(t *testing.T) {
+	e, stdout, stderr := newTestEnv(t, seatsHandler(t))
+
+	args := []string{"watch", "s1", "--section", "Normal", "--seat", "a3, b2"}
+	if code := run(context.Background(), e, args); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+	if strings.Contains(stdout.String(), "A3") || !strings.Contains(stdout.String(), "seat B2 is available") {
+		t.Fatalf("unexpected stdout: %q", stdout.String())
+	}
+}
+
+func TestWatch_RequiresCondition(t *testing.T) {
+	e, _, stderr := newTestEnv(t, seatsHandler(t))
+
+	if code := run(context.Background(), e, []string{"watch", "s1"}); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "--adjacent or --seat is required") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
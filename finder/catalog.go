@@ -0,0 +1,380 @@
+package finder
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+// SessionWithTheater is a session paired with the theater that shows it.
+type SessionWithTheater struct {
+	Session     model.TheaterSession `json:"session"`
+	Theater     model.Theater        `json:"theater"`
+	HasDistance bool                 `json:"hasDistance"`
+	DistanceKM  float64              `json:"distanceKm"`
+}
+
+// MovieAggregate groups the sessions of one movie across theaters.
+type MovieAggregate struct {
+	Movie    model.TheaterMovie   `json:"movie"`
+	Sessions []SessionWithTheater `json:"sessions"`
+}
+
+// Catalog is the result of a cross-theater movie search.
+// Failed counts theaters, or movies when the catalog was built per movie,
+// whose schedule could not be loaded. Ignored counts theaters without sessions
+// on the requested date. FailedCities counts the cities of a group whose
+// theaters could not be listed.
+type Catalog struct {
+	Movies       []MovieAggregate `json:"movies"`
+	Failed       int              `json:"failed"`
+	Ignored      int              `json:"ignored"`
+	FailedCities int              `json:"failedCities,omitempty"`
+}
+
+type theaterSessionsResult struct {
+	theater model.Theater
+	days    []model.TheaterSessionDay
+	err     error
+}
+
+type eventSessionsResult struct {
+	event model.Event
+	days  []model.EventSessionDay
+	err   error
+}
+
+// Catalog merges the movies theaters show on date. It lists the movies of the
+// city and loads each one's sessions across theaters, which takes one request
+// per movie instead of one per theater. When that fails, for example offline,
+// it falls back to the schedule of every theater, which can be served from
+// the local caches. Theaters of other cities, as in a city group, are
+// searched within their own city and merged into the same catalog.
+func (f *Finder) Catalog(ctx context.Context, cityID string, theaters []model.Theater, date time.Time, userLocation *service.UserLocation) (Catalog, error) {
+	if len(theaters) == 0 {
+		return Catalog{}, errors.New("no theaters available")
+	}
+	byCity := map[string][]model.Theater{}
+	var cityIDs []string
+	for _, theater := range theaters {
+		id := TheaterCityID(theater, cityID)
+		if _, ok := byCity[id]; !ok {
+			cityIDs = append(cityIDs, id)
+		}
+		byCity[id] = append(byCity[id], theater)
+	}
+	if len(cityIDs) == 1 {
+		return f.cityCatalog(ctx, cityIDs[0], theaters, date, userLocation)
+	}
+
+	var catalogs []Catalog
+	for _, id := range cityIDs {
+		catalog, err := f.cityCatalog(ctx, id, byCity[id], date, userLocation)
+		if err != nil {
+			return Catalog{}, err
+		}
+		catalogs = append(catalogs, catalog)
+	}
+	return mergeCatalogs(catalogs), nil
+}
+
+// CitiesCatalog merges the movies of every theater of cities on date, the
+// pipeline behind the find command, the HTTP catalog and the JSON-RPC
+// getMovieCatalog. Theaters hidden in the TUI are skipped unless includeHidden
+// is set, and each movie's sessions come nearest first. Without theaters to
+// search it returns an empty catalog.
+func (f *Finder) CitiesCatalog(ctx context.Context, cities []model.City, date time.Time, userLocation *service.UserLocation, includeHidden bool) (Catalog, error) {
+	if len(cities) == 0 {
+		return Catalog{}, errors.New("no cities to search")
+	}
+	cityID := ""
+	var theaters []model.Theater
+	var failedCities int
+	var err error
+	if len(cities) == 1 {
+		cityID = cities[0].Id
+		theaters, err = f.Theaters(ctx, cityID)
+	} else {
+		theaters, failedCities, err = f.GroupTheaters(ctx, cities)
+	}
+	if err != nil {
+		return Catalog{}, err
+	}
+	if !includeHidden {
+		cityIDs := make([]string, 0, len(cities))
+		for _, city := range cities {
+			cityIDs = append(cityIDs, city.Id)
+		}
+		hidden, err := store.LoadHiddenTheaters(cityIDs...)
+		if err != nil {
+			return Catalog{}, err
+		}
+		theaters = VisibleTheaters(theaters, hidden)
+	}
+
+	catalog := Catalog{Movies: []MovieAggregate{}}
+	if len(theaters) > 0 {
+		catalog, err = f.Catalog(ctx, cityID, theaters, date, userLocation)
+		if err != nil {
+			return Catalog{}, err
+		}
+		if catalog.Movies == nil {
+			catalog.Movies = []MovieAggregate{}
+		}
+	}
+	for _, movie := range catalog.Movies {
+		SortSessionsByDistance(movie.Sessions)
+	}
+	catalog.FailedCities = failedCities
+	return catalog, nil
+}
+
+func (f *Finder) cityCatalog(ctx context.Context, cityID string, theaters []model.Theater, date time.Time, userLocation *service.UserLocation) (Catalog, error) {
+	catalog, err := f.catalogByEvent(ctx, cityID, theaters, date, userLocation)
+	if err == nil {
+		return catalog, nil
+	}
+	if errors.Is(err, context.Canceled) {
+		return Catalog{}, err
+	}
+	return f.catalogByTheater(ctx, cityID, theaters, date, userLocation), nil
+}
+
+func (f *Finder) catalogByEvent(ctx context.Context, cityID string, theaters []model.Theater, date time.Time, userLocation *service.UserLocation) (Catalog, error) {
+	events, err := f.client.GetEventsByCity(ctx, cityID)
+	if err != nil {
+		return Catalog{}, err
+	}
+	if len(events) == 0 {
+		return Catalog{}, errors.New("no events listed")
+	}
+
+	out := make(chan eventSessionsResult, len(events))
+	var wg sync.WaitGroup
+	for _, event := range events {
+		wg.Add(1)
+		go func(event model.Event) {
+			defer wg.Done()
+			days, err := f.client.GetSessionsByCityAndEvent(ctx, cityID, event.Id, &date)
+			out <- eventSessionsResult{event: event, days: days, err: err}
+		}(event)
+	}
+	wg.Wait()
+	close(out)
+
+	results, failed, err := theaterResultsFromEvents(out, theaters, date)
+	if err != nil {
+		return Catalog{}, err
+	}
+	movies, theatersFailed, ignored := aggregateMovieCatalog(results, date, userLocation)
+	return Catalog{Movies: movies, Failed: failed + theatersFailed, Ignored: ignored}, nil
+}
+
+// theaterResultsFromEvents regroups per-movie schedules by theater, keeping
+// only the given theaters, so they merge like the per-theater crawl. It fails
+// when no movie could be loaded.
+func theaterResultsFromEvents(events <-chan eventSessionsResult, theaters []model.Theater, date time.Time) (<-chan theaterSessionsResult, int, error) {
+	index := make(map[string]int, len(theaters))
+	for i, theater := range theaters {
+		index[theater.Id] = i
+	}
+	movies := make([][]model.TheaterMovie, len(theaters))
+	failed, loaded := 0, 0
+	var firstErr error
+
+	for result := range events {
+		if result.err != nil {
+			if service.IsNotFound(result.err) {
+				loaded++
+				continue
+			}
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			failed++
+			continue
+		}
+		loaded++
+		day := selectEventDay(result.days, date)
+		for _, theater := range day.Theaters {
+			i, ok := index[theater.Id]
+			if !ok || len(theater.Rooms) == 0 {
+				continue
+			}
+			movies[i] = append(movies[i], model.TheaterMovie{
+				Id:            result.event.Id,
+				Title:         result.event.Title,
+				OriginalTitle: result.event.OriginalTitle,
+				ContentRating: result.event.ContentRating,
+				Duration:      result.event.Duration,
+				Rooms:         theater.Rooms,
+			})
+		}
+	}
+	if loaded == 0 {
+		return nil, failed, firstErr
+	}
+
+	results := make(chan theaterSessionsResult, len(theaters))
+	dateKey := date.Format(time.DateOnly)
+	for i, theater := range theaters {
+		results <- theaterSessionsResult{
+			theater: theater,
+			days:    []model.TheaterSessionDay{{Date: dateKey, Movies: movies[i]}},
+		}
+	}
+	close(results)
+	return results, failed, nil
+}
+
+// selectEventDay picks the schedule of date. Unlike SelectDay it does not
+// fall back to another day, since its sessions would be listed as date's.
+func selectEventDay(days []model.EventSessionDay, date time.Time) model.EventSessionDay {
+	target := date.Format(time.DateOnly)
+	for _, day := range days {
+		if day.Date == target {
+			return day
+		}
+	}
+	return model.EventSessionDay{}
+}
+
+// catalogByTheater loads the schedule of every theater for date.
+func (f *Finder) catalogByTheater(ctx context.Context, cityID string, theaters []model.Theater, date time.Time, userLocation *service.UserLocation) Catalog {
+	// The client's governor bounds how many of these requests run at once.
+	out := make(chan theaterSessionsResult, len(theaters))
+	var wg sync.WaitGroup
+
+	for _, theater := range theaters {
+		wg.Add(1)
+		go func(theater model.Theater) {
+			defer wg.Done()
+			days, err := f.Sessions(ctx, cityID, theater.Id, date)
+			out <- theaterSessionsResult{theater: theater, days: days, err: err}
+		}(theater)
+	}
+
+	wg.Wait()
+	close(out)
+
+	movies, failed, ignored := aggregateMovieCatalog(out, date, userLocation)
+	return Catalog{Movies: movies, Failed: failed, Ignored: ignored}
+}
+
+func aggregateMovieCatalog(results <-chan theaterSessionsResult, date time.Time, userLocation *service.UserLocation) ([]MovieAggregate, int, int) {
+	byMovie := map[string]*MovieAggregate{}
+	failed := 0
+	ignored := 0
+
+	for result := range results {
+		if result.err != nil {
+			if service.IsNotFound(result.err) {
+				ignored++
+				continue
+			}
+			failed++
+			continue
+		}
+
+		day := SelectDay(result.days, date)
+		if len(day.Movies) == 0 {
+			ignored++
+			continue
+		}
+
+		for _, movie := range day.Movies {
+			key := movieAggregateKey(movie)
+			entry := byMovie[key]
+			if entry == nil {
+				copyMovie := movie
+				copyMovie.Rooms = nil
+				entry = &MovieAggregate{Movie: copyMovie}
+				byMovie[key] = entry
+			}
+			for _, room := range movie.Rooms {
+				for _, session := range room.Sessions {
+					if strings.TrimSpace(session.Room) == "" {
+						session.Room = room.Name
+					}
+					distance, hasDistance := TheaterDistanceKM(result.theater, userLocation)
+					entry.Sessions = append(entry.Sessions, SessionWithTheater{
+						Session:     session,
+						Theater:     result.theater,
+						HasDistance: hasDistance,
+						DistanceKM:  distance,
+					})
+				}
+			}
+		}
+	}
+
+	movies := make([]MovieAggregate, 0, len(byMovie))
+	for _, movie := range byMovie {
+		movies = append(movies, *movie)
+	}
+	sort.Slice(movies, func(i, j int) bool {
+		return strings.ToLower(movies[i].Movie.Title) < strings.ToLower(movies[j].Movie.Title)
+	})
+	return movies, failed, ignored
+}
+
+// mergeCatalogs joins the catalogs of several cities, merging the sessions
+// of the same movie.
+func mergeCatalogs(catalogs []Catalog) Catalog {
+	var merged Catalog
+	byMovie := map[string]int{}
+	for _, catalog := range catalogs {
+		merged.Failed += catalog.Failed
+		merged.Ignored += catalog.Ignored
+		for _, movie := range catalog.Movies {
+			key := movieAggregateKey(movie.Movie)
+			if i, ok := byMovie[key]; ok {
+				merged.Movies[i].Sessions = append(merged.Movies[i].Sessions, movie.Sessions...)
+				continue
+			}
+			byMovie[key] = len(merged.Movies)
+			merged.Movies = append(merged.Movies, movie)
+		}
+	}
+	sort.Slice(merged.Movies, func(i, j int) bool {
+		return strings.ToLower(merged.Movies[i].Movie.Title) < strings.ToLower(merged.Movies[j].Movie.Title)
+	})
+	return merged
+}
+
+func movieAggregateKey(movie model.TheaterMovie) string {
+	if strings.TrimSpace(movie.Id) != "" {
+		return "id:" + movie.Id
+	}
+	title := strings.ToLower(strings.TrimSpace(movie.Title))
+	orig := strings.ToLower(strings.TrimSpace(movie.OriginalTitle))
+	return title + "|" + orig
+}
+
+// SortSessionsByDistance orders sessions by theater distance, then start time,
+// then theater name. Sessions without a distance come after the ones with it.
+func SortSessionsByDistance(sessions []SessionWithTheater) {
+	sort.Slice(sessions, func(i, j int) bool {
+		a := sessions[i]
+		b := sessions[j]
+
+		if a.HasDistance && b.HasDistance && math.Abs(a.DistanceKM-b.DistanceKM) > 1e-6 {
+			return a.DistanceKM < b.DistanceKM
+		}
+		if a.HasDistance != b.HasDistance {
+			return a.HasDistance
+		}
+		if !a.Session.Date.LocalDate.Equal(b.Session.Date.LocalDate) {
+			return a.Session.Date.LocalDate.Before(b.Session.Date.LocalDate)
+		}
+		return strings.ToLower(a.Theater.Name) < strings.ToLower(b.Theater.Name)
+	})
+}
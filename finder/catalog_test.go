@@ -0,0 +1,196 @@
+package finder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+)
+
+func TestAggregateMovieCatalog_MergesSameMovieAcrossTheaters(t *testing.T) {
+	date := time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)
+	sessionA := model.TheaterSession{Id: "s1"}
+	sessionA.Date.LocalDate = date.Add(19 * time.Hour)
+	sessionB := model.TheaterSession{Id: "s2"}
+	sessionB.Date.LocalDate = date.Add(21 * time.Hour)
+
+	results := make(chan theaterSessionsResult, 2)
+	results <- theaterSessionsResult{
+		theater: model.Theater{Id: "t1", Name: "Cinema 1"},
+		days: []model.TheaterSessionDay{{
+			Date: date.Format(time.DateOnly),
+			Movies: []model.TheaterMovie{{
+				Id:    "m1",
+				Title: "Movie X",
+				Rooms: []model.TheaterRoom{{Name: "Room 1", Sessions: []model.TheaterSession{sessionA}}},
+			}},
+		}},
+	}
+	results <- theaterSessionsResult{
+		theater: model.Theater{Id: "t2", Name: "Cinema 2"},
+		days: []model.TheaterSessionDay{{
+			Date: date.Format(time.DateOnly),
+			Movies: []model.TheaterMovie{{
+				Id:    "m1",
+				Title: "Movie X",
+				Rooms: []model.TheaterRoom{{Name: "Room 2", Sessions: []model.TheaterSession{sessionB}}},
+			}},
+		}},
+	}
+	close(results)
+
+	movies, failed, ignored := aggregateMovieCatalog(results, date, nil)
+	if failed != 0 || ignored != 0 {
+		t.Fatalf("expected no failures/ignored, got failed=%d ignored=%d", failed, ignored)
+	}
+	if len(movies) != 1 {
+		t.Fatalf("expected 1 merged movie, got %d", len(movies))
+	}
+	if len(movies[0].Sessions) != 2 {
+		t.Fatalf("expected 2 sessions in merged movie, got %d", len(movies[0].Sessions))
+	}
+}
+
+func TestAggregateMovieCatalog_CountsFailedAndIgnored(t *testing.T) {
+	date := time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)
+
+	results := make(chan theaterSessionsResult, 3)
+	results <- theaterSessionsResult{theater: model.Theater{Id: "t1"}, err: errTest("boom")}
+	results <- theaterSessionsResult{theater: model.Theater{Id: "t2"}, err: &service.APIError{StatusCode: http.StatusNotFound}}
+	results <- theaterSessionsResult{theater: model.Theater{Id: "t3"}, days: []model.TheaterSessionDay{{Date: date.Format(time.DateOnly)}}}
+	close(results)
+
+	movies, failed, ignored := aggregateMovieCatalog(results, date, nil)
+	if len(movies) != 0 {
+		t.Fatalf("expected no movies, got %d", len(movies))
+	}
+	if failed != 1 || ignored != 2 {
+		t.Fatalf("expected failed=1 ignored=2, got failed=%d ignored=%d", failed, ignored)
+	}
+}
+
+func TestCatalog_UsesPerMovieSessions(t *testing.T) {
+	var theaterRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/events/city/1":
+			_, _ = w.Write([]byte(`[{"id":"m1","title":"Duna: Parte 2","duration":"166"},{"id":"m2","title":"Anora"}]`))
+		case r.URL.Path == "/sessions/city/1/event/m1":
+			if r.URL.Query().Get("date") != "2026-10-20" {
+				t.Errorf("unexpected date: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"date":"2026-10-20","theaters":[
+				{"id":"t1","name":"Cinema 1","rooms":[{"name":"Sala 1","sessions":[{"id":"s1","date":{"localDate":"2026-10-20T19:00:00-03:00"}}]}]},
+				{"id":"hidden","name":"Hidden","rooms":[{"name":"Sala 9","sessions":[{"id":"s9"}]}]}
+			]}]`))
+		case r.URL.Path == "/sessions/city/1/event/m2":
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasPrefix(r.URL.Path, "/sessions/city/1/theater/"):
+			atomic.AddInt32(&theaterRequests, 1)
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	f := New(service.NewClient(server.Client(), service.WithBaseURL(server.URL)))
+	theaters := []model.Theater{{Id: "t1", Name: "Cinema 1"}, {Id: "t2", Name: "Cinema 2"}}
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	catalog, err := f.Catalog(context.Background(), "1", theaters, date, nil)
+	if err != nil {
+		t.Fatalf("Catalog() error = %v", err)
+	}
+	if atomic.LoadInt32(&theaterRequests) != 0 {
+		t.Fatal("expected no per-theater requests")
+	}
+	if len(catalog.Movies) != 1 || catalog.Movies[0].Movie.Title != "Duna: Parte 2" || catalog.Movies[0].Movie.Duration != "166" {
+		t.Fatalf("unexpected movies: %+v", catalog.Movies)
+	}
+	sessions := catalog.Movies[0].Sessions
+	if len(sessions) != 1 || sessions[0].Theater.Id != "t1" || sessions[0].Session.Room != "Sala 1" {
+		t.Fatalf("expected only the visible theater's session, got %+v", sessions)
+	}
+	if catalog.Failed != 0 || catalog.Ignored != 1 {
+		t.Fatalf("expected failed=0 ignored=1, got failed=%d ignored=%d", catalog.Failed, catalog.Ignored)
+	}
+}
+
+func TestCatalog_IgnoresSessionsOfAnotherDay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/events/city/1":
+			_, _ = w.Write([]byte(`[{"id":"m1","title":"Duna: Parte 2"}]`))
+		case "/sessions/city/1/event/m1":
+			_, _ = w.Write([]byte(`[{"date":"2026-10-21","theaters":[{"id":"t1","name":"Cinema 1","rooms":[{"name":"Sala 1","sessions":[{"id":"s1"}]}]}]}]`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	f := New(service.NewClient(server.Client(), service.WithBaseURL(server.URL)))
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	catalog, err := f.Catalog(context.Background(), "1", []model.Theater{{Id: "t1", Name: "Cinema 1"}}, date, nil)
+	if err != nil {
+		t.Fatalf("Catalog() error = %v", err)
+	}
+	if len(catalog.Movies) != 0 || catalog.Ignored != 1 {
+		t.Fatalf("expected the next day's sessions left out, got %+v", catalog)
+	}
+}
+
+func TestSortSessionsByDistance(t *testing.T) {
+	base := time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)
+	early := model.TheaterSession{Id: "early"}
+	early.Date.LocalDate = base.Add(18 * time.Hour)
+	late := model.TheaterSession{Id: "late"}
+	late.Date.LocalDate = base.Add(21 * time.Hour)
+
+	sessions := []SessionWithTheater{
+		{Session: early, Theater: model.Theater{Name: "Unknown"}},
+		{Session: late, Theater: model.Theater{Name: "Far"}, HasDistance: true, DistanceKM: 12},
+		{Session: late, Theater: model.Theater{Name: "Near"}, HasDistance: true, DistanceKM: 2},
+	}
+	SortSessionsByDistance(sessions)
+
+	got := []string{sessions[0].Theater.Name, sessions[1].Theater.Name, sessions[2].Theater.Name}
+	if got[0] != "Near" || got[1] != "Far" || got[2] != "Unknown" {
+		t.Fatalf("unexpected order: %v", got)
+	}
+}
+
+type errTest string
+
+func (e errTest) Error() string { return string(e) }
+
+func TestCatalog_MergesCitiesOfAGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/events/city/1", "/events/city/2":
+			w.WriteHeader(http.StatusNotFound)
+		case "/sessions/city/1/theater/t1", "/sessions/city/2/theater/t2":
+			_, _ = w.Write([]byte(`[{"date":"2026-10-20","movies":[{"id":"m1","title":"Duna: Parte 2","rooms":[{"name":"Sala 1","sessions":[{"id":"s-` + r.URL.Path[len(r.URL.Path)-2:] + `"}]}]}]}]`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	f := New(service.NewClient(server.Client(), service.WithBaseURL(server.URL)))
+	theaters := []model.Theater{{Id: "t1", Name: "Cinema SP", CityId: "1"}, {Id: "t2", Name: "Cinema Guarulhos", CityId: "2"}}
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	catalog, err := f.Catalog(context.Background(), "", theaters, date, nil)
+	if err != nil {
+		t.Fatalf("Catalog() error = %v", err)
+	}
+	if len(catalog.Movies) != 1 || len(catalog.Movies[0].Sessions) != 2 {
+		t.Fatalf("expected one movie with the sessions of both cities, got %+v", catalog.Movies)
+	}
+}
@@ -0,0 +1,67 @@
+package finder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+// NewClient builds the API client from config.json, then the INGRESSO_*
+// environment overrides, then opts, each taking precedence over the previous.
+func NewClient(opts ...service.Option) (*service.Client, error) {
+	cfg, err := store.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	configOpts, err := apiConfigOptions(cfg.API)
+	if err != nil {
+		return nil, err
+	}
+	envOpts, err := service.EnvOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	all := append(configOpts, envOpts...)
+	return service.NewClient(nil, append(all, opts...)...), nil
+}
+
+func apiConfigOptions(cfg store.APIConfig) ([]service.Option, error) {
+	timeout, err := parseConfigDuration("timeout", cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	retryBase, err := parseConfigDuration("retry_base", cfg.RetryBase)
+	if err != nil {
+		return nil, err
+	}
+	retryCap, err := parseConfigDuration("retry_cap", cfg.RetryCap)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxAttempts < 0 {
+		return nil, fmt.Errorf("invalid api.max_attempts %d in config", cfg.MaxAttempts)
+	}
+	return []service.Option{
+		service.WithBaseURL(cfg.BaseURL),
+		service.WithCheckoutURL(cfg.CheckoutURL),
+		service.WithUserAgent(cfg.UserAgent),
+		service.WithRetries(cfg.MaxAttempts, retryBase, retryCap),
+		service.WithTimeout(timeout),
+	}, nil
+}
+
+func parseConfigDuration(name, value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid api.%s %q in config", name, value)
+	}
+	return duration, nil
+}
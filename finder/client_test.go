@@ -0,0 +1,56 @@
+package finder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ingresso-finder-cli/internal/ingressotest"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+func TestNewClient_ConfigThenEnv(t *testing.T) {
+	ingressotest.IsolateStore(t)
+
+	var gotAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAgent = r.Header.Get("User-Agent")
+		_, _ = w.Write([]byte(`[{"uf":"SP","cities":[{"id":"1","name":"Sao Paulo"}]}]`))
+	}))
+	defer server.Close()
+
+	path, err := store.ConfigPath()
+	if err != nil {
+		t.Fatalf("config path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	config := `{"api":{"base_url":"http://127.0.0.1:1/v0","user_agent":"from-config","timeout":"2s"}}`
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("INGRESSO_API_URL", server.URL)
+
+	client, err := NewClient(service.WithRetries(1, 0, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetCities(context.Background()); err != nil {
+		t.Fatalf("expected the env base url to win over the config: %v", err)
+	}
+	if gotAgent != "from-config" {
+		t.Fatalf("expected the config user agent, got %q", gotAgent)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"api":{"timeout":"soon"}}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := NewClient(); err == nil {
+		t.Fatal("expected an invalid config error")
+	}
+}
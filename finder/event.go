@@ -0,0 +1,61 @@
+package finder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/ics"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+)
+
+// defaultSessionLength is used when the movie duration is unknown.
+const defaultSessionLength = 2 * time.Hour
+
+// SessionEvent builds a calendar event for a session. The event ends after the
+// movie duration and points at the theater address and the checkout page.
+func SessionEvent(movie model.TheaterMovie, session model.TheaterSession, theater model.Theater) ics.Event {
+	start := session.Date.LocalDate
+	length := defaultSessionLength
+	if minutes, err := strconv.Atoi(strings.TrimSpace(movie.Duration)); err == nil && minutes > 0 {
+		length = time.Duration(minutes) * time.Minute
+	}
+
+	checkout := service.CheckoutURL(session.Id)
+	details := []string{}
+	if room := strings.TrimSpace(session.Room); room != "" {
+		details = append(details, room)
+	}
+	if len(session.Type) > 0 {
+		details = append(details, strings.Join(session.Type, ", "))
+	}
+	description := checkout
+	if len(details) > 0 {
+		description = strings.Join(details, " • ") + "\n" + checkout
+	}
+
+	return ics.Event{
+		UID:         fmt.Sprintf("%s@ingresso-finder-cli", session.Id),
+		Start:       start,
+		End:         start.Add(length),
+		Summary:     fmt.Sprintf("%s • %s", movie.Title, theater.Name),
+		Location:    theaterLocation(theater),
+		Description: description,
+		URL:         checkout,
+	}
+}
+
+func theaterLocation(theater model.Theater) string {
+	parts := []string{}
+	for _, part := range []string{theater.Name, theater.Address, theater.Neighborhood, theater.City} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if uf := strings.TrimSpace(theater.Uf); uf != "" && len(parts) > 0 {
+		parts[len(parts)-1] += " - " + uf
+	}
+	return strings.Join(parts, ", ")
+}
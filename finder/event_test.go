@@ -0,0 +1,43 @@
+package finder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ingresso-finder-cli/model"
+)
+
+func TestSessionEvent(t *testing.T) {
+	start := time.Date(2026, 10, 20, 18, 30, 0, 0, time.UTC)
+	session := model.TheaterSession{Id: "s1", Room: "Sala 3", Type: []string{"Legendado", "IMAX"}}
+	session.Date.LocalDate = start
+	theater := model.Theater{Name: "Cinemark", Address: "Av. Paulista, 2073", City: "São Paulo", Uf: "SP"}
+
+	event := SessionEvent(model.TheaterMovie{Title: "Duna", Duration: "155"}, session, theater)
+
+	if !event.End.Equal(start.Add(155 * time.Minute)) {
+		t.Fatalf("expected end after the movie duration, got %v", event.End)
+	}
+	if event.Summary != "Duna • Cinemark" {
+		t.Fatalf("unexpected summary: %q", event.Summary)
+	}
+	if event.Location != "Cinemark, Av. Paulista, 2073, São Paulo - SP" {
+		t.Fatalf("unexpected location: %q", event.Location)
+	}
+	if !strings.HasPrefix(event.Description, "Sala 3 • Legendado, IMAX\n") || !strings.Contains(event.Description, "s1") {
+		t.Fatalf("unexpected description: %q", event.Description)
+	}
+}
+
+func TestSessionEvent_DefaultLength(t *testing.T) {
+	start := time.Date(2026, 10, 20, 18, 30, 0, 0, time.UTC)
+	session := model.TheaterSession{Id: "s1"}
+	session.Date.LocalDate = start
+
+	event := SessionEvent(model.TheaterMovie{Title: "Duna"}, session, model.Theater{Name: "Cinemark"})
+
+	if !event.End.Equal(start.Add(defaultSessionLength)) {
+		t.Fatalf("expected default length, got %v", event.End.Sub(start))
+	}
+}
@@ -0,0 +1,276 @@
+// Package finder combines the Ingresso API client with the local store caches
+// so the TUI and the headless commands share the same lookup rules.
+package finder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+// Finder resolves cities, theaters and sessions, preferring fresh cache entries.
+// When a request fails, stale cache entries are served instead; see TrackStale
+// to learn how old they were.
+type Finder struct {
+	client *service.Client
+}
+
+// New creates a Finder backed by the given client. If client is nil, a default client is used.
+func New(client *service.Client) *Finder {
+	if client == nil {
+		client = service.NewClient(nil)
+	}
+	return &Finder{client: client}
+}
+
+// Client returns the underlying API client.
+func (f *Finder) Client() *service.Client {
+	return f.client
+}
+
+// Staleness records the stale cache entries served during the calls made with
+// the context returned by TrackStale.
+type Staleness struct {
+	mu    sync.Mutex
+	since time.Time
+}
+
+type stalenessKey struct{}
+
+// TrackStale returns a context that records, in the returned Staleness, the
+// stale cache entries the Finder serves for calls made with it. Each caller
+// tracks its own calls, so a concurrent request that succeeds does not hide
+// the stale data another one served.
+func TrackStale(ctx context.Context) (context.Context, *Staleness) {
+	stale := &Staleness{}
+	return context.WithValue(ctx, stalenessKey{}, stale), stale
+}
+
+// Since returns when the oldest stale cache entry served was saved. It is
+// zero when every answer was fresh.
+func (s *Staleness) Since() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.since
+}
+
+func markStale(ctx context.Context, updatedAt time.Time) {
+	s, ok := ctx.Value(stalenessKey{}).(*Staleness)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.since.IsZero() || updatedAt.Before(s.since) {
+		s.since = updatedAt
+	}
+}
+
+// lookupCached answers from a fresh cache entry, then from fetch, saving what
+// it returns. If fetch fails for any reason but a 404 or a canceled request,
+// a stale cache entry is served instead. Cache answers are reported as
+// service events named after key.
+func lookupCached[T any](ctx context.Context, key string, load func() ([]T, store.CacheInfo, error), fetch func(context.Context) ([]T, error), save func([]T) error) ([]T, error) {
+	cached, info, cacheErr := load()
+	usable := cacheErr == nil && len(cached) > 0
+	if usable && info.Fresh {
+		service.Emit(service.RequestEvent{Source: service.SourceIngresso, URL: key, Cache: service.CacheStore})
+		return cached, nil
+	}
+	data, err := fetch(ctx)
+	if err != nil {
+		if usable && !service.IsNotFound(err) && !errors.Is(err, context.Canceled) {
+			markStale(ctx, info.UpdatedAt)
+			service.Emit(service.RequestEvent{Source: service.SourceIngresso, URL: key, Cache: service.CacheStoreStale, Err: err.Error()})
+			return cached, nil
+		}
+		return nil, err
+	}
+	if len(data) > 0 {
+		_ = save(data)
+	}
+	return data, nil
+}
+
+// Cities returns every city known to the API, using the local cache while it is fresh.
+func (f *Finder) Cities(ctx context.Context) ([]model.City, error) {
+	return lookupCached(ctx, "cities", store.LoadCityCache, f.client.GetCities, store.SaveCityCache)
+}
+
+// CityByName resolves a city by its display name. The cached city list and
+// the recent cities answer first, ignoring case and accents, so known cities
+// resolve offline; names they miss, or match more than once, are asked to the
+// API.
+func (f *Finder) CityByName(ctx context.Context, name string) (model.City, error) {
+	cities, err := lookupCached(ctx, "city_"+FoldName(name),
+		func() ([]model.City, store.CacheInfo, error) { return cachedCitiesNamed(name) },
+		func(ctx context.Context) ([]model.City, error) {
+			city, err := f.client.GetCityInfoByName(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			return []model.City{city}, nil
+		},
+		// A single city must not replace the cached list of every city.
+		func([]model.City) error { return nil },
+	)
+	if err != nil {
+		return model.City{}, err
+	}
+	return cities[0], nil
+}
+
+// cachedCitiesNamed returns the cached or recent cities called name. A single
+// match is fresh however old the cache is, since a city keeps its id.
+func cachedCitiesNamed(name string) ([]model.City, store.CacheInfo, error) {
+	target := FoldName(name)
+	if target == "" {
+		return nil, store.CacheInfo{}, nil
+	}
+	cached, info, _ := store.LoadCityCache()
+	var matches []model.City
+	for _, city := range cached {
+		if FoldName(city.Name) == target {
+			matches = append(matches, city)
+		}
+	}
+	if len(matches) == 0 {
+		recents, _ := store.LoadRecentCities()
+		for _, recent := range recents {
+			if recent.ID != "" && FoldName(recent.Name) == target {
+				matches = append(matches, model.City{Id: recent.ID, Name: recent.Name, Uf: recent.UF})
+			}
+		}
+	}
+	info.Fresh = len(matches) == 1
+	return matches, info, nil
+}
+
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// FoldName lowercases a place name and drops its accents, so "Sao Paulo"
+// matches "São Paulo".
+func FoldName(name string) string {
+	return accentFolder.Replace(strings.ToLower(strings.Join(strings.Fields(name), " ")))
+}
+
+// Theaters returns the theaters of a city, using the local cache while it is fresh.
+// Each theater carries cityID, so theaters of several cities can be mixed.
+func (f *Finder) Theaters(ctx context.Context, cityID string) ([]model.Theater, error) {
+	theaters, err := lookupCached(ctx, "theaters_"+cityID,
+		func() ([]model.Theater, store.CacheInfo, error) { return store.LoadTheaterCache(cityID) },
+		func(ctx context.Context) ([]model.Theater, error) { return f.client.GetTheatersByCity(ctx, cityID) },
+		func(theaters []model.Theater) error { return store.SaveTheaterCache(cityID, theaters) },
+	)
+	for i := range theaters {
+		if theaters[i].CityId == "" {
+			theaters[i].CityId = cityID
+		}
+	}
+	return theaters, err
+}
+
+// GroupTheaters returns the theaters of every city of a group, in the order of
+// the cities. Theaters that do not name their city get the name of the one
+// they were listed for. A city whose theaters cannot be loaded is skipped and
+// counted in failed; it fails only when no city could be loaded.
+func (f *Finder) GroupTheaters(ctx context.Context, cities []model.City) (theaters []model.Theater, failed int, err error) {
+	var firstErr error
+	for _, city := range cities {
+		cityTheaters, err := f.Theaters(ctx, city.Id)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, 0, ctxErr
+			}
+			if firstErr == nil {
+				firstErr = fmt.Errorf("theaters of %s: %w", city.Name, err)
+			}
+			failed++
+			continue
+		}
+		for i := range cityTheaters {
+			if cityTheaters[i].City == "" {
+				cityTheaters[i].City = city.Name
+			}
+		}
+		theaters = append(theaters, cityTheaters...)
+	}
+	if len(cities) > 0 && failed == len(cities) {
+		return nil, failed, firstErr
+	}
+	return theaters, failed, nil
+}
+
+// TheaterCityID returns the city a theater belongs to, or fallback for
+// theaters that do not say.
+func TheaterCityID(theater model.Theater, fallback string) string {
+	if theater.CityId != "" {
+		return theater.CityId
+	}
+	return fallback
+}
+
+// VisibleTheaters drops the theaters present in hidden, preserving order.
+func VisibleTheaters(theaters []model.Theater, hidden map[string]bool) []model.Theater {
+	visible := make([]model.Theater, 0, len(theaters))
+	for _, theater := range theaters {
+		if hidden[theater.Id] {
+			continue
+		}
+		visible = append(visible, theater)
+	}
+	return visible
+}
+
+// FindTheater picks a theater by id or name. Names match case-insensitively,
+// first exactly and then as a unique substring.
+func FindTheater(theaters []model.Theater, query string) (model.Theater, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return model.Theater{}, errors.New("theater id or name is required")
+	}
+	for _, theater := range theaters {
+		if theater.Id == query {
+			return theater, nil
+		}
+	}
+	for _, theater := range theaters {
+		if strings.EqualFold(theater.Name, query) {
+			return theater, nil
+		}
+	}
+
+	needle := strings.ToLower(query)
+	var matches []model.Theater
+	for _, theater := range theaters {
+		if strings.Contains(strings.ToLower(theater.Name), needle) {
+			matches = append(matches, theater)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return model.Theater{}, fmt.Errorf("theater %q not found", query)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, 0, len(matches))
+		for _, theater := range matches {
+			names = append(names, theater.Name)
+		}
+		return model.Theater{}, fmt.Errorf("theater %q is ambiguous (%s)", query, strings.Join(names, ", "))
+	}
+}
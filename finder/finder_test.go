@@ -0,0 +1,135 @@
+package finder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+func TestFindTheater(t *testing.T) {
+	theaters := []model.Theater{
+		{Id: "10", Name: "Cinemark Paulista"},
+		{Id: "11", Name: "Cinemark Eldorado"},
+		{Id: "12", Name: "Reserva Cultural"},
+	}
+
+	cases := map[string]string{
+		"10":                "10",
+		"reserva cultural":  "12",
+		"eldorado":          "11",
+		"CINEMARK PAULISTA": "10",
+	}
+	for query, want := range cases {
+		theater, err := FindTheater(theaters, query)
+		if err != nil {
+			t.Fatalf("query %q: unexpected error %v", query, err)
+		}
+		if theater.Id != want {
+			t.Fatalf("query %q: expected %s, got %s", query, want, theater.Id)
+		}
+	}
+
+	if _, err := FindTheater(theaters, "cinemark"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+	if _, err := FindTheater(theaters, "imax"); err == nil {
+		t.Fatal("expected not found error")
+	}
+}
+
+func TestMovieSessions_FillsRoomAndSortsByTime(t *testing.T) {
+	base := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	late := model.TheaterSession{Id: "late"}
+	late.Date.LocalDate = base.Add(21 * time.Hour)
+	early := model.TheaterSession{Id: "early", Room: "Sala VIP"}
+	early.Date.LocalDate = base.Add(18 * time.Hour)
+
+	sessions := MovieSessions(model.TheaterMovie{Rooms: []model.TheaterRoom{
+		{Name: "Sala 1", Sessions: []model.TheaterSession{late}},
+		{Name: "Sala 2", Sessions: []model.TheaterSession{early}},
+	}})
+
+	if len(sessions) != 2 || sessions[0].Id != "early" || sessions[1].Id != "late" {
+		t.Fatalf("unexpected order: %+v", sessions)
+	}
+	if sessions[0].Room != "Sala VIP" || sessions[1].Room != "Sala 1" {
+		t.Fatalf("unexpected rooms: %q, %q", sessions[0].Room, sessions[1].Room)
+	}
+}
+
+func TestCities_ServesStaleCacheWhenOffline(t *testing.T) {
+	dir := t.TempDir()
+	store.SetCacheDir(dir)
+	t.Cleanup(func() { store.SetCacheDir("") })
+
+	savedAt := time.Date(2026, 10, 1, 14, 32, 0, 0, time.UTC)
+	payload, _ := json.Marshal(map[string]any{
+		"updated_at": savedAt,
+		"data":       []model.City{{Id: "1", Name: "São Paulo", Uf: "SP"}},
+	})
+	if err := os.WriteFile(filepath.Join(dir, "cities.json"), payload, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := New(service.NewClient(&http.Client{Transport: service.OfflineTransport()}))
+	ctx, stale := TrackStale(context.Background())
+	cities, err := f.Cities(ctx)
+	if err != nil {
+		t.Fatalf("expected the stale cache, got %v", err)
+	}
+	if len(cities) != 1 || cities[0].Id != "1" {
+		t.Fatalf("unexpected cities: %+v", cities)
+	}
+	if got := stale.Since(); !got.Equal(savedAt) {
+		t.Fatalf("expected stale since %s, got %s", savedAt, got)
+	}
+	if _, other := TrackStale(context.Background()); !other.Since().IsZero() {
+		t.Fatalf("expected another call to see no stale data, got %s", other.Since())
+	}
+
+	if _, err := f.Theaters(context.Background(), "1"); !errors.Is(err, service.ErrOffline) {
+		t.Fatalf("expected ErrOffline without a cache, got %v", err)
+	}
+}
+
+func TestEvent_CachesDetails(t *testing.T) {
+	store.SetCacheDir(t.TempDir())
+	t.Cleanup(func() { store.SetCacheDir("") })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events/m1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"m1","title":"Duna: Parte 2","synopsis":"Paul Atreides se une a Chani.","director":"Denis Villeneuve","genres":["Ficção Científica"],"trailers":[{"url":"https://youtu.be/duna"}]}`))
+	}))
+	defer server.Close()
+
+	f := New(service.NewClient(server.Client(), service.WithBaseURL(server.URL)))
+	event, err := f.Event(context.Background(), "m1")
+	if err != nil {
+		t.Fatalf("Event() error = %v", err)
+	}
+	if event.Synopsis != "Paul Atreides se une a Chani." || event.Director != "Denis Villeneuve" || len(event.Trailers) != 1 {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	offline := New(service.NewClient(&http.Client{Transport: service.OfflineTransport()}))
+	cached, err := offline.Event(context.Background(), "m1")
+	if err != nil {
+		t.Fatalf("expected the cached event, got %v", err)
+	}
+	if cached.Title != "Duna: Parte 2" || len(cached.Genres) != 1 {
+		t.Fatalf("unexpected cached event: %+v", cached)
+	}
+}
@@ -0,0 +1,89 @@
+package finder
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+)
+
+// SortTheatersByDistance orders theaters by distance to location, keeping
+// theaters without coordinates at the end in alphabetical order.
+func SortTheatersByDistance(theaters []model.Theater, location *service.UserLocation) {
+	sort.Slice(theaters, func(i, j int) bool {
+		leftDist, leftOK := TheaterDistanceKM(theaters[i], location)
+		rightDist, rightOK := TheaterDistanceKM(theaters[j], location)
+		if leftOK && rightOK && math.Abs(leftDist-rightDist) > 1e-6 {
+			return leftDist < rightDist
+		}
+		if leftOK != rightOK {
+			return leftOK
+		}
+		return strings.ToLower(theaters[i].Name) < strings.ToLower(theaters[j].Name)
+	})
+}
+
+// TheaterDistanceKM returns the distance between the theater and location, if both are known.
+func TheaterDistanceKM(theater model.Theater, location *service.UserLocation) (float64, bool) {
+	if location == nil {
+		return 0, false
+	}
+	if theater.Geolocation.Lat == 0 && theater.Geolocation.Lng == 0 {
+		return 0, false
+	}
+	distance := HaversineKM(location.Latitude, location.Longitude, theater.Geolocation.Lat, theater.Geolocation.Lng)
+	return distance, true
+}
+
+// HaversineKM returns the great-circle distance in kilometers between two coordinates.
+func HaversineKM(lat1 float64, lon1 float64, lat2 float64, lon2 float64) float64 {
+	const earthRadius = 6371.0
+	toRad := math.Pi / 180
+
+	lat1Rad := lat1 * toRad
+	lon1Rad := lon1 * toRad
+	lat2Rad := lat2 * toRad
+	lon2Rad := lon2 * toRad
+
+	dLat := lat2Rad - lat1Rad
+	dLon := lon2Rad - lon1Rad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1Rad)*math.Cos(lat2Rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return earthRadius * c
+}
+
+// ParseNear parses an optional "lat,lng" reference point, returning nil when
+// value is blank.
+func ParseNear(value string) (*service.UserLocation, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	location, err := ParseCoordinates(value)
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// ParseCoordinates parses a "lat,lng" pair into a manual location.
+func ParseCoordinates(value string) (service.UserLocation, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return service.UserLocation{}, errors.New("expected lat,lng")
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return service.UserLocation{}, errors.New("invalid latitude")
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return service.UserLocation{}, errors.New("invalid longitude")
+	}
+	return service.UserLocation{Latitude: lat, Longitude: lng, Source: "manual"}, nil
+}
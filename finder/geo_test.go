@@ -0,0 +1,46 @@
+package finder
+
+import (
+	"math"
+	"testing"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+)
+
+func TestHaversineKM_SaoPauloToRio(t *testing.T) {
+	distance := HaversineKM(-23.5505, -46.6333, -22.9068, -43.1729)
+	if math.Abs(distance-361) > 5 {
+		t.Fatalf("expected roughly 361 km, got %.1f", distance)
+	}
+}
+
+func TestSortTheatersByDistance_UnknownCoordinatesLast(t *testing.T) {
+	near := model.Theater{Id: "near", Name: "Near"}
+	near.Geolocation.Lat = -23.55
+	near.Geolocation.Lng = -46.63
+	far := model.Theater{Id: "far", Name: "Far"}
+	far.Geolocation.Lat = -22.90
+	far.Geolocation.Lng = -43.17
+	unknown := model.Theater{Id: "unknown", Name: "Alpha"}
+
+	theaters := []model.Theater{unknown, far, near}
+	SortTheatersByDistance(theaters, &service.UserLocation{Latitude: -23.55, Longitude: -46.63})
+
+	got := []string{theaters[0].Id, theaters[1].Id, theaters[2].Id}
+	want := []string{"near", "far", "unknown"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, got)
+		}
+	}
+}
+
+func TestTheaterDistanceKM_NoLocation(t *testing.T) {
+	theater := model.Theater{Id: "1"}
+	theater.Geolocation.Lat = -23.55
+	theater.Geolocation.Lng = -46.63
+	if _, ok := TheaterDistanceKM(theater, nil); ok {
+		t.Fatal("expected no distance without a location")
+	}
+}
@@ -0,0 +1,47 @@
+package finder
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+// defaultMetadataProviders puts TMDb first for its Portuguese synopses and
+// keeps OMDb for the IMDb and Rotten Tomatoes ratings.
+var defaultMetadataProviders = []string{"tmdb", "omdb"}
+
+// NewMetadataProvider chains the metadata providers named by
+// INGRESSO_METADATA_PROVIDERS (comma separated) or metadata.providers in
+// config.json. Providers without an API key are skipped at lookup time.
+func NewMetadataProvider() (*service.MetadataChain, error) {
+	cfg, err := store.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	names := cfg.Metadata.Providers
+	if value := strings.TrimSpace(os.Getenv("INGRESSO_METADATA_PROVIDERS")); value != "" {
+		names = strings.Split(value, ",")
+	}
+	return metadataChain(names)
+}
+
+func metadataChain(names []string) (*service.MetadataChain, error) {
+	if len(names) == 0 {
+		names = defaultMetadataProviders
+	}
+	var providers []service.MetadataProvider
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "omdb":
+			providers = append(providers, service.NewOMDbClientFromEnv())
+		case "tmdb":
+			providers = append(providers, service.NewTMDbClientFromEnv())
+		default:
+			return nil, fmt.Errorf("unknown metadata provider %q", name)
+		}
+	}
+	return service.NewMetadataChain(providers...), nil
+}
@@ -0,0 +1,49 @@
+package finder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ingresso-finder-cli/internal/ingressotest"
+	"ingresso-finder-cli/store"
+)
+
+func TestNewMetadataProvider_ConfigThenEnv(t *testing.T) {
+	ingressotest.IsolateStore(t)
+	t.Setenv("OMDB_API_KEY", "omdb-key")
+	t.Setenv("TMDB_API_KEY", "tmdb-key")
+	t.Setenv("INGRESSO_METADATA_PROVIDERS", "")
+
+	provider, err := NewMetadataProvider()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.Name() != "tmdb+omdb" {
+		t.Fatalf("default providers = %q, want tmdb+omdb", provider.Name())
+	}
+
+	path, err := store.ConfigPath()
+	if err != nil {
+		t.Fatalf("config path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"metadata":{"providers":["omdb"]}}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if provider, err = NewMetadataProvider(); err != nil || provider.Name() != "omdb" {
+		t.Fatalf("config providers = %v, %v; want omdb", provider, err)
+	}
+
+	t.Setenv("INGRESSO_METADATA_PROVIDERS", "omdb, tmdb")
+	if provider, err = NewMetadataProvider(); err != nil || provider.Name() != "omdb+tmdb" {
+		t.Fatalf("env providers = %v, %v; want omdb+tmdb", provider, err)
+	}
+
+	t.Setenv("INGRESSO_METADATA_PROVIDERS", "imdb")
+	if _, err := NewMetadataProvider(); err == nil {
+		t.Fatal("expected an unknown provider error")
+	}
+}
@@ -0,0 +1,33 @@
+package finder
+
+import (
+	"context"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/store"
+)
+
+// Event returns Ingresso's details of a movie, using the local cache while it is fresh.
+func (f *Finder) Event(ctx context.Context, eventID string) (model.Event, error) {
+	events, err := lookupCached(ctx, "event_"+eventID,
+		func() ([]model.Event, store.CacheInfo, error) {
+			event, info, err := store.LoadEventCache(eventID)
+			if err != nil || event.Id == "" {
+				return nil, info, err
+			}
+			return []model.Event{event}, info, nil
+		},
+		func(ctx context.Context) ([]model.Event, error) {
+			event, err := f.client.GetEvent(ctx, eventID)
+			if err != nil {
+				return nil, err
+			}
+			return []model.Event{event}, nil
+		},
+		func(events []model.Event) error { return store.SaveEventCache(eventID, events[0]) },
+	)
+	if err != nil {
+		return model.Event{}, err
+	}
+	return events[0], nil
+}
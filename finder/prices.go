@@ -0,0 +1,188 @@
+package finder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+)
+
+// SectionPrices is what one section of a session charges. Tickets is empty
+// when the checkout API did not list the section's ticket categories, or when
+// loading them failed with Err.
+type SectionPrices struct {
+	Section model.SessionSection
+	VIP     bool
+	Tickets []model.Ticket
+	Err     error
+}
+
+// SessionPrices returns the ticket categories of every section of a session,
+// regular sections first. The sections load concurrently, as far as the
+// client's governor lets them. Sections whose tickets cannot be loaded keep
+// the price range of the session details and carry the error.
+func (f *Finder) SessionPrices(ctx context.Context, sessionID string) ([]SectionPrices, error) {
+	detail, err := f.client.GetSessionDetails(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	prices := make([]SectionPrices, len(detail.Sections))
+	var wg sync.WaitGroup
+	for i, section := range detail.Sections {
+		wg.Add(1)
+		go func(i int, section model.SessionSection) {
+			defer wg.Done()
+			tickets, err := f.client.GetSectionTickets(ctx, sessionID, section.Id)
+			if service.IsNotFound(err) {
+				err = nil
+			}
+			prices[i] = SectionPrices{Section: section, VIP: IsVIPSection(section), Tickets: tickets, Err: err}
+		}(i, section)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(prices, func(i, j int) bool { return !prices[i].VIP && prices[j].VIP })
+	return prices, nil
+}
+
+// IsVIPSection reports whether a section is sold apart from the regular seats,
+// like the VIP rows some rooms have.
+func IsVIPSection(section model.SessionSection) bool {
+	return strings.Contains(strings.ToLower(section.Name), "vip")
+}
+
+// TicketPrice is what a ticket costs with the service fee.
+func TicketPrice(ticket model.Ticket) float64 {
+	if ticket.Total > 0 {
+		return ticket.Total
+	}
+	return ticket.Price
+}
+
+// Range returns the cheapest and the most expensive ticket of the section.
+func (s SectionPrices) Range() (low, high float64) {
+	if len(s.Tickets) == 0 {
+		return s.Section.LowestPrice, s.Section.HighestPrice
+	}
+	for i, ticket := range s.Tickets {
+		price := TicketPrice(ticket)
+		if i == 0 || price < low {
+			low = price
+		}
+		high = max(high, price)
+	}
+	return low, high
+}
+
+// Ticket returns the first ticket whose name contains category, ignoring
+// case and a plural "s" ("meias" finds "Meia-Entrada").
+func (s SectionPrices) Ticket(category string) (model.Ticket, bool) {
+	category = normalizeTicketCategory(category)
+	for _, ticket := range s.Tickets {
+		if strings.Contains(strings.ToLower(ticket.Name), category) {
+			return ticket, true
+		}
+	}
+	return model.Ticket{}, false
+}
+
+// PriceRange returns the price range of the regular or the VIP sections.
+// ok is false when none of them has a price.
+func PriceRange(sections []SectionPrices, vip bool) (low, high float64, ok bool) {
+	for _, section := range sections {
+		if section.VIP != vip {
+			continue
+		}
+		sectionLow, sectionHigh := section.Range()
+		if sectionHigh <= 0 {
+			continue
+		}
+		if !ok || sectionLow < low {
+			low = sectionLow
+		}
+		high = max(high, sectionHigh)
+		ok = true
+	}
+	return low, high, ok
+}
+
+// TicketCount is one part of a ticket mix, like "2 inteira".
+type TicketCount struct {
+	Quantity int
+	Category string
+}
+
+// ticketAliases lets the mix use the English names the session list shows.
+var ticketAliases = map[string]string{
+	"full": "inteira",
+	"half": "meia",
+}
+
+func normalizeTicketCategory(category string) string {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if alias, ok := ticketAliases[category]; ok {
+		return alias
+	}
+	if len(category) > 4 {
+		category = strings.TrimSuffix(category, "s")
+	}
+	return category
+}
+
+// ParseTicketMix reads a mix like "2 inteira + 1 meia". Parts are separated
+// by "+" or "," and a part without a quantity counts once.
+func ParseTicketMix(raw string) ([]TicketCount, error) {
+	var mix []TicketCount
+	for _, part := range strings.FieldsFunc(raw, func(r rune) bool { return r == '+' || r == ',' }) {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		count := TicketCount{Quantity: 1}
+		if n, err := strconv.Atoi(fields[0]); err == nil {
+			if n <= 0 {
+				return nil, fmt.Errorf("invalid ticket quantity %q", fields[0])
+			}
+			count.Quantity = n
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("missing ticket category in %q", strings.TrimSpace(part))
+		}
+		count.Category = normalizeTicketCategory(strings.Join(fields, " "))
+		mix = append(mix, count)
+	}
+	if len(mix) == 0 {
+		return nil, fmt.Errorf("empty ticket mix")
+	}
+	return mix, nil
+}
+
+// FormatTicketMix writes a mix back as "2 inteira + 1 meia".
+func FormatTicketMix(mix []TicketCount) string {
+	parts := make([]string, 0, len(mix))
+	for _, count := range mix {
+		parts = append(parts, fmt.Sprintf("%d %s", count.Quantity, count.Category))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// MixTotal totals a ticket mix with the tickets of a section.
+func MixTotal(section SectionPrices, mix []TicketCount) (float64, error) {
+	var total float64
+	for _, count := range mix {
+		ticket, ok := section.Ticket(count.Category)
+		if !ok {
+			return 0, fmt.Errorf("no %q ticket in %s", count.Category, section.Section.Name)
+		}
+		total += float64(count.Quantity) * TicketPrice(ticket)
+	}
+	return total, nil
+}
@@ -0,0 +1,93 @@
+package finder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+)
+
+func TestParseTicketMix(t *testing.T) {
+	mix, err := ParseTicketMix("2 inteiras + 1 Meia, full")
+	if err != nil {
+		t.Fatalf("ParseTicketMix() error = %v", err)
+	}
+	if got := FormatTicketMix(mix); got != "2 inteira + 1 meia + 1 inteira" {
+		t.Fatalf("unexpected mix: %q", got)
+	}
+
+	for _, raw := range []string{"", "0 meia", "2"} {
+		if _, err := ParseTicketMix(raw); err == nil {
+			t.Errorf("ParseTicketMix(%q) should fail", raw)
+		}
+	}
+}
+
+func TestMixTotal(t *testing.T) {
+	section := SectionPrices{
+		Section: model.SessionSection{Name: "Sala 1"},
+		Tickets: []model.Ticket{
+			{Name: "Inteira", Price: 40, Service: 6, Total: 46},
+			{Name: "Meia-Entrada", Price: 20, Service: 3, Total: 23},
+		},
+	}
+	mix, _ := ParseTicketMix("2 inteira + 1 meia")
+	total, err := MixTotal(section, mix)
+	if err != nil {
+		t.Fatalf("MixTotal() error = %v", err)
+	}
+	if total != 115 {
+		t.Fatalf("expected 115, got %.2f", total)
+	}
+
+	mix, _ = ParseTicketMix("1 idoso")
+	if _, err := MixTotal(section, mix); err == nil {
+		t.Fatal("expected an error for a category the section does not sell")
+	}
+}
+
+func TestSessionPrices_SeparatesVIPSections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sessions/s1":
+			_, _ = w.Write([]byte(`{"id":"s1","sections":[
+				{"id":"vip","name":"Poltronas VIP","lowestPrice":80,"highestPrice":80},
+				{"id":"std","name":"Sala 1","lowestPrice":20,"highestPrice":40},
+				{"id":"dbox","name":"Sala 1 D-BOX","lowestPrice":50,"highestPrice":70}
+			]}`))
+		case "/sessions/s1/sections/std/tickets":
+			_, _ = w.Write([]byte(`[{"name":"Inteira","price":40,"total":46},{"name":"Meia-Entrada","price":20,"total":23}]`))
+		case "/sessions/s1/sections/vip/tickets":
+			w.WriteHeader(http.StatusNotFound)
+		case "/sessions/s1/sections/dbox/tickets":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	f := New(service.NewClient(server.Client(), service.WithBaseURL(server.URL), service.WithCheckoutURL(server.URL)))
+	prices, err := f.SessionPrices(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("SessionPrices() error = %v", err)
+	}
+	if len(prices) != 3 || prices[0].Section.Id != "std" || prices[1].Section.Id != "dbox" || !prices[2].VIP {
+		t.Fatalf("expected the regular sections first, got %+v", prices)
+	}
+	if prices[0].Err != nil || prices[2].Err != nil {
+		t.Fatalf("expected unlisted tickets not to be errors, got %v and %v", prices[0].Err, prices[2].Err)
+	}
+	if prices[1].Err == nil {
+		t.Fatal("expected the section whose tickets failed to carry the error")
+	}
+	if low, high, ok := PriceRange(prices, false); !ok || low != 23 || high != 70 {
+		t.Fatalf("unexpected regular range: %.2f - %.2f", low, high)
+	}
+	if low, high, ok := PriceRange(prices, true); !ok || low != 80 || high != 80 {
+		t.Fatalf("expected the VIP range from the session details, got %.2f - %.2f", low, high)
+	}
+}
@@ -0,0 +1,176 @@
+package finder
+
+import (
+	"sort"
+	"strings"
+
+	"ingresso-finder-cli/model"
+)
+
+// FrontRowCount is the number of rows closest to the screen that are not considered ideal.
+const FrontRowCount = 3
+
+// SeatSummary counts the seats of a seat map by status.
+type SeatSummary struct {
+	Available         int `json:"available"`
+	Occupied          int `json:"occupied"`
+	Blocked           int `json:"blocked"`
+	Total             int `json:"total"`
+	NonIdealAvailable int `json:"nonIdealAvailable"`
+	IdealAvailable    int `json:"idealAvailable"`
+	PairAvailable     int `json:"pairAvailable"`
+}
+
+// Add returns the sum of both summaries.
+func (s SeatSummary) Add(other SeatSummary) SeatSummary {
+	return SeatSummary{
+		Available:         s.Available + other.Available,
+		Occupied:          s.Occupied + other.Occupied,
+		Blocked:           s.Blocked + other.Blocked,
+		Total:             s.Total + other.Total,
+		NonIdealAvailable: s.NonIdealAvailable + other.NonIdealAvailable,
+		IdealAvailable:    s.IdealAvailable + other.IdealAvailable,
+		PairAvailable:     s.PairAvailable + other.PairAvailable,
+	}
+}
+
+// CountSeats summarizes a seat map. Available seats in the front rows are
+// counted as non-ideal and pairs are non-overlapping adjacent available seats.
+func CountSeats(seatMap model.SeatMap) SeatSummary {
+	var result SeatSummary
+	front := FrontLines(seatMap, FrontRowCount)
+	for _, line := range seatMap.Lines {
+		for _, seat := range line.Seats {
+			result.Total++
+			status := strings.ToLower(seat.Status)
+			switch status {
+			case "available":
+				result.Available++
+				if front[seat.Line] {
+					result.NonIdealAvailable++
+				}
+			case "occupied":
+				result.Occupied++
+			case "blocked", "unavailable":
+				result.Blocked++
+			}
+		}
+	}
+	result.IdealAvailable = max(0, result.Available-result.NonIdealAvailable)
+	result.PairAvailable = len(AdjacentBlocks(seatMap, 2))
+	return result
+}
+
+// AdjacentBlocks returns the non-overlapping runs of size available seats that
+// sit side by side in the same line, ordered by line and column.
+func AdjacentBlocks(seatMap model.SeatMap, size int) [][]model.Seat {
+	if size <= 0 {
+		return nil
+	}
+	byLine := map[int][]model.Seat{}
+	for _, line := range seatMap.Lines {
+		for _, seat := range line.Seats {
+			if strings.EqualFold(seat.Status, "available") && seat.Column > 0 {
+				byLine[seat.Line] = append(byLine[seat.Line], seat)
+			}
+		}
+	}
+	lines := make([]int, 0, len(byLine))
+	for line := range byLine {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+
+	var blocks [][]model.Seat
+	for _, line := range lines {
+		seats := byLine[line]
+		sort.Slice(seats, func(i, j int) bool { return seats[i].Column < seats[j].Column })
+		start := 0
+		for i := range seats {
+			if i > start && seats[i].Column != seats[i-1].Column+1 {
+				start = i
+			}
+			if i-start+1 == size {
+				blocks = append(blocks, append([]model.Seat(nil), seats[start:i+1]...))
+				start = i + 1
+			}
+		}
+	}
+	return blocks
+}
+
+// FreeSeats returns the labels that are available in the seat map, compared
+// with NormalizeSeatLabel so "G 12" matches "g12".
+func FreeSeats(seatMap model.SeatMap, labels []string) []string {
+	available := map[string]bool{}
+	for _, line := range seatMap.Lines {
+		for _, seat := range line.Seats {
+			if strings.EqualFold(seat.Status, "available") {
+				available[NormalizeSeatLabel(seat.Label)] = true
+			}
+		}
+	}
+	var free []string
+	for _, label := range labels {
+		if normalized := NormalizeSeatLabel(label); available[normalized] {
+			free = append(free, normalized)
+		}
+	}
+	return free
+}
+
+// NormalizeSeatLabel uppercases a seat label and drops its whitespace.
+func NormalizeSeatLabel(label string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(label), ""))
+}
+
+// BlockLabel describes a block of seats as "G10-G13".
+func BlockLabel(block []model.Seat) string {
+	if len(block) == 0 {
+		return ""
+	}
+	first := NormalizeSeatLabel(block[0].Label)
+	if len(block) == 1 {
+		return first
+	}
+	return first + "-" + NormalizeSeatLabel(block[len(block)-1].Label)
+}
+
+// SeatSections keeps only the sections that support seat selection.
+func SeatSections(sections []model.SessionSection) []model.SessionSection {
+	var filtered []model.SessionSection
+	for _, section := range sections {
+		if section.HasSeatSelection {
+			filtered = append(filtered, section)
+		}
+	}
+	return filtered
+}
+
+// FrontLines returns the count lines with the highest numbers, which are the ones closest to the screen.
+func FrontLines(seatMap model.SeatMap, count int) map[int]bool {
+	lines := map[int]bool{}
+	for _, line := range seatMap.Lines {
+		for _, seat := range line.Seats {
+			if seat.Line > 0 {
+				lines[seat.Line] = true
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return map[int]bool{}
+	}
+	var keys []int
+	for k := range lines {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	if count > len(keys) {
+		count = len(keys)
+	}
+	front := make(map[int]bool, count)
+	for i := len(keys) - count; i < len(keys); i++ {
+		front[keys[i]] = true
+	}
+	return front
+}
@@ -0,0 +1,96 @@
+package finder
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"ingresso-finder-cli/model"
+)
+
+func seatRow(line int, statuses ...string) model.SeatLine {
+	row := model.SeatLine{Line: line}
+	for i, status := range statuses {
+		row.Seats = append(row.Seats, model.Seat{Status: status, Line: line, Column: i + 1})
+	}
+	return row
+}
+
+func TestCountSeats(t *testing.T) {
+	seatMap := model.SeatMap{Lines: []model.SeatLine{
+		seatRow(1, "Available", "Available", "Available", "Occupied"),
+		seatRow(2, "Available", "Blocked", "Available", "Available"),
+		seatRow(3, "Occupied", "Occupied", "Unavailable", "Occupied"),
+		seatRow(4, "Occupied", "Occupied", "Occupied", "Available"),
+		seatRow(5, "Available", "Available", "Occupied", "Occupied"),
+	}}
+
+	summary := CountSeats(seatMap)
+	want := SeatSummary{
+		Available:         9,
+		Occupied:          9,
+		Blocked:           2,
+		Total:             20,
+		NonIdealAvailable: 3,
+		IdealAvailable:    6,
+		PairAvailable:     3,
+	}
+	if summary != want {
+		t.Fatalf("expected %+v, got %+v", want, summary)
+	}
+}
+
+func TestSeatSummary_Add(t *testing.T) {
+	total := SeatSummary{Available: 1, Total: 2}.Add(SeatSummary{Available: 3, Occupied: 1, Total: 4})
+	if total.Available != 4 || total.Occupied != 1 || total.Total != 6 {
+		t.Fatalf("unexpected sum: %+v", total)
+	}
+}
+
+func TestAdjacentBlocks(t *testing.T) {
+	seatMap := model.SeatMap{Lines: []model.SeatLine{
+		seatRow(1, "Available", "Available", "Available", "Occupied", "Available"),
+		seatRow(2, "Available", "Available", "Available", "Available", "Available"),
+	}}
+	for i := range seatMap.Lines {
+		for j := range seatMap.Lines[i].Seats {
+			seat := &seatMap.Lines[i].Seats[j]
+			seat.Label = fmt.Sprintf("%c %d", 'A'+i, seat.Column)
+		}
+	}
+
+	blocks := AdjacentBlocks(seatMap, 3)
+	got := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		got = append(got, BlockLabel(block))
+	}
+	if strings.Join(got, ",") != "A1-A3,B1-B3" {
+		t.Fatalf("unexpected blocks: %v", got)
+	}
+	if n := len(AdjacentBlocks(seatMap, 6)); n != 0 {
+		t.Fatalf("expected no block of 6, got %d", n)
+	}
+	if n := len(AdjacentBlocks(seatMap, 1)); n != 9 {
+		t.Fatalf("expected every available seat as a block of 1, got %d", n)
+	}
+}
+
+func TestSeatWatch_Check(t *testing.T) {
+	seatMap := model.SeatMap{Lines: []model.SeatLine{
+		{Line: 7, Seats: []model.Seat{
+			{Label: "G 11", Status: "Occupied", Line: 7, Column: 11},
+			{Label: "G 12", Status: "Available", Line: 7, Column: 12},
+		}},
+	}}
+
+	alert := SeatWatch{Adjacent: 2, Labels: []string{"g12", "G11"}}.Check(seatMap)
+	if !alert.Triggered() {
+		t.Fatal("expected the free label to trigger the alert")
+	}
+	if len(alert.Blocks) != 0 || strings.Join(alert.Labels, ",") != "G12" {
+		t.Fatalf("unexpected alert: %+v", alert)
+	}
+	if (SeatWatch{Adjacent: 2}).Check(seatMap).Triggered() {
+		t.Fatal("expected no pair in a single free seat")
+	}
+}
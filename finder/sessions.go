@@ -0,0 +1,84 @@
+package finder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/store"
+)
+
+// Sessions returns the schedule of a theater for date, using the local cache while it is fresh.
+func (f *Finder) Sessions(ctx context.Context, cityID string, theaterID string, date time.Time) ([]model.TheaterSessionDay, error) {
+	dateKey := date.Format(time.DateOnly)
+	return lookupCached(ctx, fmt.Sprintf("sessions_%s_%s_%s", cityID, theaterID, dateKey),
+		func() ([]model.TheaterSessionDay, store.CacheInfo, error) {
+			return store.LoadSessionCache(cityID, theaterID, dateKey)
+		},
+		func(ctx context.Context) ([]model.TheaterSessionDay, error) {
+			return f.client.GetSessionsByCityAndTheater(ctx, cityID, theaterID, &date)
+		},
+		func(days []model.TheaterSessionDay) error {
+			return store.SaveSessionCache(cityID, theaterID, dateKey, days)
+		},
+	)
+}
+
+// SelectDay picks the schedule matching date, falling back to the first day returned.
+func SelectDay(days []model.TheaterSessionDay, date time.Time) model.TheaterSessionDay {
+	if day, ok := FindDay(days, date); ok {
+		return day
+	}
+	if len(days) == 0 {
+		return model.TheaterSessionDay{}
+	}
+	return days[0]
+}
+
+// FindDay returns the schedule of date, reporting false when days has none.
+func FindDay(days []model.TheaterSessionDay, date time.Time) (model.TheaterSessionDay, bool) {
+	target := date.Format(time.DateOnly)
+	for _, day := range days {
+		if day.Date == target {
+			return day, true
+		}
+	}
+	return model.TheaterSessionDay{}, false
+}
+
+// ParseDate reads an optional YYYY-MM-DD date in the local time zone,
+// defaulting to today.
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	now := time.Now()
+	if value == "" {
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
+	}
+	date, err := time.ParseInLocation(time.DateOnly, value, now.Location())
+	if err != nil {
+		return time.Time{}, errors.New("expected YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+// MovieSessions flattens the rooms of a movie into sessions ordered by start time.
+// Sessions without a room name inherit the name of the room they are listed under.
+func MovieSessions(movie model.TheaterMovie) []model.TheaterSession {
+	var sessions []model.TheaterSession
+	for _, room := range movie.Rooms {
+		for _, session := range room.Sessions {
+			if strings.TrimSpace(session.Room) == "" {
+				session.Room = room.Name
+			}
+			sessions = append(sessions, session)
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].Date.LocalDate.Before(sessions[j].Date.LocalDate)
+	})
+	return sessions
+}
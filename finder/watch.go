@@ -0,0 +1,40 @@
+package finder
+
+import (
+	"ingresso-finder-cli/model"
+)
+
+// SeatWatch describes the seats someone is waiting for. It fires when a block
+// of Adjacent seats is available or when any of Labels becomes free.
+type SeatWatch struct {
+	Adjacent int
+	Labels   []string
+}
+
+// SeatAlert lists what a seat map offers for a SeatWatch.
+type SeatAlert struct {
+	Blocks [][]model.Seat
+	Labels []string
+}
+
+// Triggered reports whether the alert found anything.
+func (a SeatAlert) Triggered() bool {
+	return len(a.Blocks) > 0 || len(a.Labels) > 0
+}
+
+// Enabled reports whether the watch has anything to look for.
+func (w SeatWatch) Enabled() bool {
+	return w.Adjacent > 0 || len(w.Labels) > 0
+}
+
+// Check matches the watch against a seat map.
+func (w SeatWatch) Check(seatMap model.SeatMap) SeatAlert {
+	var alert SeatAlert
+	if w.Adjacent > 0 {
+		alert.Blocks = AdjacentBlocks(seatMap, w.Adjacent)
+	}
+	if len(w.Labels) > 0 {
+		alert.Labels = FreeSeats(seatMap, w.Labels)
+	}
+	return alert
+}
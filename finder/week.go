@@ -0,0 +1,265 @@
+package finder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+// scheduleCacheKey is the date slot of the session cache holding the
+// multi-day schedule returned when no date is requested.
+const scheduleCacheKey = "all"
+
+// DaySessions is the sessions of one day of a date range.
+type DaySessions struct {
+	Date     time.Time            `json:"date"`
+	Sessions []SessionWithTheater `json:"sessions"`
+}
+
+// Schedule returns every day a theater has published sessions for, using the
+// local cache while it is fresh.
+func (f *Finder) Schedule(ctx context.Context, cityID string, theaterID string) ([]model.TheaterSessionDay, error) {
+	return lookupCached(ctx, fmt.Sprintf("sessions_%s_%s_%s", cityID, theaterID, scheduleCacheKey),
+		func() ([]model.TheaterSessionDay, store.CacheInfo, error) {
+			return store.LoadSessionCache(cityID, theaterID, scheduleCacheKey)
+		},
+		func(ctx context.Context) ([]model.TheaterSessionDay, error) {
+			return f.client.GetSessionsByCityAndTheater(ctx, cityID, theaterID, nil)
+		},
+		func(days []model.TheaterSessionDay) error {
+			return store.SaveSessionCache(cityID, theaterID, scheduleCacheKey, days)
+		},
+	)
+}
+
+// schedules loads the schedule of every theater at once, leaving the pacing
+// to the client's governor. Theaters of a city group are asked within their
+// own city.
+func (f *Finder) schedules(ctx context.Context, cityID string, theaters []model.Theater) []theaterSessionsResult {
+	results := make([]theaterSessionsResult, len(theaters))
+	var wg sync.WaitGroup
+	for i, theater := range theaters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			days, err := f.Schedule(ctx, TheaterCityID(theater, cityID), theater.Id)
+			results[i] = theaterSessionsResult{theater: theater, days: days, err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// movieSchedules loads the schedule of movie in every theater from the
+// sessions of the movie across theaters, one request per city instead of one
+// per theater. Each theater's days list only that movie. Cities where the
+// movie is not showing (not found) leave their theaters empty.
+func (f *Finder) movieSchedules(ctx context.Context, cityID string, theaters []model.Theater, movie model.TheaterMovie) ([]theaterSessionsResult, error) {
+	if strings.TrimSpace(movie.Id) == "" {
+		return nil, errors.New("movie id is required")
+	}
+	results := make([]theaterSessionsResult, len(theaters))
+	index := make(map[string]int, len(theaters))
+	var cityIDs []string
+	seen := map[string]bool{}
+	for i, theater := range theaters {
+		results[i].theater = theater
+		index[theater.Id] = i
+		if id := TheaterCityID(theater, cityID); !seen[id] {
+			seen[id] = true
+			cityIDs = append(cityIDs, id)
+		}
+	}
+
+	for _, id := range cityIDs {
+		days, err := f.client.GetSessionsByCityAndEvent(ctx, id, movie.Id, nil)
+		if service.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, day := range days {
+			for _, theater := range day.Theaters {
+				i, ok := index[theater.Id]
+				if !ok || len(theater.Rooms) == 0 {
+					continue
+				}
+				showing := movie
+				showing.Rooms = theater.Rooms
+				results[i].days = append(results[i].days, model.TheaterSessionDay{Date: day.Date, Movies: []model.TheaterMovie{showing}})
+			}
+		}
+	}
+	return results, nil
+}
+
+// scheduleError reports why no schedule could be used: the cancellation, or
+// the first failure when every theater failed. Theaters without a schedule
+// (not found) do not count as failures.
+func scheduleError(ctx context.Context, results []theaterSessionsResult) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	failed := 0
+	var firstErr error
+	for _, result := range results {
+		if result.err == nil || service.IsNotFound(result.err) {
+			continue
+		}
+		failed++
+		if firstErr == nil {
+			firstErr = result.err
+		}
+	}
+	if len(results) > 0 && failed == len(results) {
+		return failed, firstErr
+	}
+	return failed, nil
+}
+
+// movieScheduleResults loads the days movie is showing in theaters; MovieWeek
+// and MovieDays both read them, so the week view and the date picker agree.
+// A single theater uses its own schedule, the one AvailableDays reads. Several
+// ask for the movie's sessions across theaters, one request per city, and fall
+// back to each theater's schedule when that fails, for example offline, where
+// the local caches can serve them. failed counts the theaters whose schedule
+// could not be loaded. The days may list other movies; callers keep the
+// showings matching movieAggregateKey.
+func (f *Finder) movieScheduleResults(ctx context.Context, cityID string, theaters []model.Theater, movie model.TheaterMovie) ([]theaterSessionsResult, int, error) {
+	if len(theaters) == 0 {
+		return nil, 0, errors.New("no theaters available")
+	}
+	if len(theaters) > 1 {
+		results, err := f.movieSchedules(ctx, cityID, theaters, movie)
+		if err == nil {
+			return results, 0, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, 0, ctxErr
+		}
+	}
+	results := f.schedules(ctx, cityID, theaters)
+	failed, err := scheduleError(ctx, results)
+	if err != nil {
+		return nil, failed, err
+	}
+	return results, failed, nil
+}
+
+// AvailableDays returns the dates, as YYYY-MM-DD, on which theater has
+// sessions of any movie. Ingresso has no city-wide list of days, so across
+// theaters the date picker asks MovieDays instead.
+func (f *Finder) AvailableDays(ctx context.Context, cityID string, theater model.Theater) (map[string]bool, error) {
+	schedule, err := f.Schedule(ctx, TheaterCityID(theater, cityID), theater.Id)
+	if err != nil && !service.IsNotFound(err) {
+		return nil, err
+	}
+	days := map[string]bool{}
+	for _, day := range schedule {
+		if len(day.Movies) > 0 {
+			days[day.Date] = true
+		}
+	}
+	return days, nil
+}
+
+// MovieDays returns the dates, as YYYY-MM-DD, on which movie has sessions in
+// any of the theaters, from the same schedules as MovieWeek. It fails only
+// when no schedule could be loaded.
+func (f *Finder) MovieDays(ctx context.Context, cityID string, theaters []model.Theater, movie model.TheaterMovie) (map[string]bool, error) {
+	results, _, err := f.movieScheduleResults(ctx, cityID, theaters, movie)
+	if err != nil {
+		return nil, err
+	}
+	key := movieAggregateKey(movie)
+	days := map[string]bool{}
+	for _, result := range results {
+		for _, day := range result.days {
+			for _, showing := range day.Movies {
+				if movieAggregateKey(showing) == key && len(MovieSessions(showing)) > 0 {
+					days[day.Date] = true
+				}
+			}
+		}
+	}
+	return days, nil
+}
+
+// MovieWeek returns the sessions of movie on every day from from to to,
+// inclusive, across theaters, with one entry per day even when it has no
+// sessions. The schedules are loaded as for MovieDays. failed counts the
+// theaters whose schedule could not be loaded.
+func (f *Finder) MovieWeek(ctx context.Context, cityID string, theaters []model.Theater, movie model.TheaterMovie, from, to time.Time, userLocation *service.UserLocation) ([]DaySessions, int, error) {
+	if len(theaters) == 0 {
+		return nil, 0, errors.New("no theaters available")
+	}
+	from, to = truncateDay(from), truncateDay(to)
+	if to.Before(from) {
+		return nil, 0, errors.New("the date range ends before it starts")
+	}
+	results, failed, err := f.movieScheduleResults(ctx, cityID, theaters, movie)
+	if err != nil {
+		return nil, failed, err
+	}
+
+	var week []DaySessions
+	index := map[string]int{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		index[day.Format(time.DateOnly)] = len(week)
+		week = append(week, DaySessions{Date: day})
+	}
+
+	key := movieAggregateKey(movie)
+	for _, result := range results {
+		distance, hasDistance := TheaterDistanceKM(result.theater, userLocation)
+		for _, day := range result.days {
+			i, ok := index[day.Date]
+			if !ok {
+				continue
+			}
+			for _, showing := range day.Movies {
+				if movieAggregateKey(showing) != key {
+					continue
+				}
+				for _, session := range MovieSessions(showing) {
+					week[i].Sessions = append(week[i].Sessions, SessionWithTheater{
+						Session:     session,
+						Theater:     result.theater,
+						HasDistance: hasDistance,
+						DistanceKM:  distance,
+					})
+				}
+			}
+		}
+	}
+	for _, day := range week {
+		sort.SliceStable(day.Sessions, func(i, j int) bool {
+			a, b := day.Sessions[i], day.Sessions[j]
+			if a.Theater.Id != b.Theater.Id {
+				if a.HasDistance && b.HasDistance && a.DistanceKM != b.DistanceKM {
+					return a.DistanceKM < b.DistanceKM
+				}
+				if nameA, nameB := strings.ToLower(a.Theater.Name), strings.ToLower(b.Theater.Name); nameA != nameB {
+					return nameA < nameB
+				}
+				return a.Theater.Id < b.Theater.Id
+			}
+			return a.Session.Date.LocalDate.Before(b.Session.Date.LocalDate)
+		})
+	}
+	return week, failed, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,143 @@
+package finder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+// newScheduleServer serves theater schedules and, with byEvent, the sessions
+// of movie m1 across theaters; without it that endpoint fails.
+func newScheduleServer(t *testing.T, byEvent bool) *httptest.Server {
+	t.Helper()
+	store.SetCacheDir(t.TempDir())
+	t.Cleanup(func() { store.SetCacheDir("") })
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("date") != "" {
+			t.Errorf("expected the schedule without a date, got %s", r.URL.RawQuery)
+		}
+		if byEvent && strings.Contains(r.URL.Path, "/theater/") {
+			t.Errorf("expected one request for the movie, got %s", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/sessions/city/1/event/m1":
+			if !byEvent {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`[
+				{"date":"2026-10-16","theaters":[{"id":"t1","name":"Cinema A","rooms":[{"name":"Sala 1","sessions":[{"id":"s1","date":{"localDate":"2026-10-16T21:00:00-03:00"}},{"id":"s2","date":{"localDate":"2026-10-16T14:00:00-03:00"}}]}]},{"id":"t9","name":"Outro","rooms":[{"name":"Sala 9","sessions":[{"id":"s9"}]}]}]},
+				{"date":"2026-10-17","theaters":[{"id":"t2","name":"Cinema B","rooms":[{"name":"Sala 3","sessions":[{"id":"s4"}]}]}]}
+			]`))
+		case "/sessions/city/1/theater/t1":
+			_, _ = w.Write([]byte(`[
+				{"date":"2026-10-16","movies":[{"id":"m1","title":"Duna: Parte 2","rooms":[{"name":"Sala 1","sessions":[{"id":"s1","date":{"localDate":"2026-10-16T21:00:00-03:00"}},{"id":"s2","date":{"localDate":"2026-10-16T14:00:00-03:00"}}]}]}]},
+				{"date":"2026-10-18","movies":[{"id":"m2","title":"Wicked","rooms":[{"name":"Sala 2","sessions":[{"id":"s3"}]}]}]}
+			]`))
+		case "/sessions/city/1/theater/t2":
+			_, _ = w.Write([]byte(`[{"date":"2026-10-17","movies":[{"id":"m1","title":"Duna: Parte 2","rooms":[{"name":"Sala 3","sessions":[{"id":"s4"}]}]}]}]`))
+		case "/sessions/city/1/theater/t3":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestMovieWeek_ListsEveryDayOfTheRange(t *testing.T) {
+	for _, byEvent := range []bool{true, false} {
+		server := newScheduleServer(t, byEvent)
+		defer server.Close()
+
+		f := New(service.NewClient(server.Client(), service.WithBaseURL(server.URL)))
+		checkMovieWeek(t, f)
+	}
+}
+
+func checkMovieWeek(t *testing.T, f *Finder) {
+	t.Helper()
+	theaters := []model.Theater{{Id: "t1", Name: "Cinema A"}, {Id: "t2", Name: "Cinema B"}, {Id: "t3", Name: "Cinema C"}}
+	from := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	week, failed, err := f.MovieWeek(context.Background(), "1", theaters, model.TheaterMovie{Id: "m1"}, from, from.AddDate(0, 0, 2), nil)
+	if err != nil {
+		t.Fatalf("MovieWeek() error = %v", err)
+	}
+	if failed != 0 {
+		t.Fatalf("expected theaters without a schedule not to count as failed, got %d", failed)
+	}
+	if len(week) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(week))
+	}
+	if ids := sessionIDs(week[0].Sessions); len(ids) != 2 || ids[0] != "s2" || ids[1] != "s1" {
+		t.Fatalf("expected Friday's sessions by time, got %v", ids)
+	}
+	if ids := sessionIDs(week[1].Sessions); len(ids) != 1 || ids[0] != "s4" || week[1].Sessions[0].Session.Room != "Sala 3" {
+		t.Fatalf("unexpected Saturday sessions: %+v", week[1].Sessions)
+	}
+	if len(week[2].Sessions) != 0 {
+		t.Fatalf("expected no sessions of the movie on Sunday, got %+v", week[2].Sessions)
+	}
+}
+
+func TestAvailableDays(t *testing.T) {
+	server := newScheduleServer(t, false)
+	defer server.Close()
+
+	f := New(service.NewClient(server.Client(), service.WithBaseURL(server.URL)))
+	days, err := f.AvailableDays(context.Background(), "1", model.Theater{Id: "t1"})
+	if err != nil {
+		t.Fatalf("AvailableDays() error = %v", err)
+	}
+	if len(days) != 2 || !days["2026-10-16"] || !days["2026-10-18"] {
+		t.Fatalf("expected the days of the theater's schedule, got %v", days)
+	}
+	if days, err := f.AvailableDays(context.Background(), "1", model.Theater{Id: "t3"}); err != nil || len(days) != 0 {
+		t.Fatalf("expected no days for a theater without a schedule, got %v (%v)", days, err)
+	}
+
+	offline := New(service.NewClient(&http.Client{Transport: service.OfflineTransport()}))
+	if _, err := offline.AvailableDays(context.Background(), "1", model.Theater{Id: "t1"}); err != nil {
+		t.Fatalf("expected the cached schedule offline, got %v", err)
+	}
+}
+
+func TestMovieDays_MatchMovieWeek(t *testing.T) {
+	for _, byEvent := range []bool{true, false} {
+		server := newScheduleServer(t, byEvent)
+		defer server.Close()
+
+		f := New(service.NewClient(server.Client(), service.WithBaseURL(server.URL)))
+		theaters := []model.Theater{{Id: "t1"}, {Id: "t2"}, {Id: "t3"}}
+		days, err := f.MovieDays(context.Background(), "1", theaters, model.TheaterMovie{Id: "m1"})
+		if err != nil {
+			t.Fatalf("MovieDays() error = %v", err)
+		}
+		if len(days) != 2 || !days["2026-10-16"] || !days["2026-10-17"] {
+			t.Fatalf("expected the movie's days only (byEvent %v), got %v", byEvent, days)
+		}
+	}
+
+	server := newScheduleServer(t, false)
+	defer server.Close()
+	f := New(service.NewClient(server.Client(), service.WithBaseURL(server.URL)))
+	days, err := f.MovieDays(context.Background(), "1", []model.Theater{{Id: "t1"}}, model.TheaterMovie{Id: "m1"})
+	if err != nil || len(days) != 1 || !days["2026-10-16"] {
+		t.Fatalf("expected the movie's days in the theater's schedule, got %v (%v)", days, err)
+	}
+}
+
+func sessionIDs(sessions []SessionWithTheater) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.Session.Id)
+	}
+	return ids
+}
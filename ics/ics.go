@@ -0,0 +1,103 @@
+// Package ics writes iCalendar (RFC 5545) files.
+package ics
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	productID  = "-//ingresso-finder-cli//EN"
+	timeLayout = "20060102T150405Z"
+	maxLineLen = 75
+)
+
+// Event is a single VEVENT entry.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Location    string
+	Description string
+	URL         string
+}
+
+// Write encodes events as a VCALENDAR document.
+func Write(w io.Writer, events []Event) error {
+	return write(w, events, time.Now())
+}
+
+func write(w io.Writer, events []Event, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", productID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	for _, event := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", escapeText(event.UID))
+		line("DTSTAMP", formatTime(stamp))
+		line("DTSTART", formatTime(event.Start))
+		if !event.End.IsZero() {
+			line("DTEND", formatTime(event.End))
+		}
+		line("SUMMARY", escapeText(event.Summary))
+		if event.Location != "" {
+			line("LOCATION", escapeText(event.Location))
+		}
+		if event.Description != "" {
+			line("DESCRIPTION", escapeText(event.Description))
+		}
+		if event.URL != "" {
+			line("URL", event.URL)
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeFolded writes a content line, folding it at 75 octets without splitting UTF-8 sequences.
+func writeFolded(w *bufio.Writer, content string) {
+	limit := maxLineLen
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut--
+		}
+		_, _ = w.WriteString(content[:cut])
+		_, _ = w.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = maxLineLen - 1
+	}
+	_, _ = w.WriteString(content)
+	_, _ = w.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
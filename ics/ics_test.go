@@ -0,0 +1,54 @@
+package ics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite_EncodesEvent(t *testing.T) {
+	start := time.Date(2026, 10, 20, 21, 0, 0, 0, time.FixedZone("BRT", -3*3600))
+	var buf bytes.Buffer
+	err := write(&buf, []Event{{
+		UID:         "s1@ingresso-finder-cli",
+		Start:       start,
+		End:         start.Add(155 * time.Minute),
+		Summary:     "Duna: Parte 2",
+		Location:    "Cinemark Paulista, Av. Paulista, 2073",
+		Description: "Sala 1; Legendado\nhttps://example.com",
+	}}, time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20261021T000000Z\r\n",
+		"DTEND:20261021T023500Z\r\n",
+		"DTSTAMP:20261015T120000Z\r\n",
+		"LOCATION:Cinemark Paulista\\, Av. Paulista\\, 2073\r\n",
+		"DESCRIPTION:Sala 1\\; Legendado\\nhttps://example.com\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteFolded_LongLines(t *testing.T) {
+	var buf bytes.Buffer
+	if err := write(&buf, []Event{{UID: "1", Summary: strings.Repeat("ã", 80)}}, time.Now()); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > maxLineLen {
+			t.Fatalf("line longer than %d octets: %q", maxLineLen, line)
+		}
+	}
+	if !strings.Contains(buf.String(), "\r\n ") {
+		t.Fatal("expected folded continuation lines")
+	}
+}
@@ -0,0 +1,48 @@
+// Package ingressotest points finders at fake Ingresso servers in tests and
+// keeps tests away from the user's config and caches.
+package ingressotest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"ingresso-finder-cli/service"
+)
+
+// IsolateStore points HOME and the XDG directories at a temporary directory,
+// so the store reads and writes nothing outside the test.
+func IsolateStore(t testing.TB) {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	t.Setenv("XDG_CONFIG_HOME", root)
+	t.Setenv("XDG_CACHE_HOME", root)
+}
+
+// NewClient starts a test server for handler and returns a client that sends
+// every Ingresso request to it. The server is closed when the test ends.
+func NewClient(t testing.TB, handler http.Handler) *service.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parse server url: %v", err)
+	}
+	return service.NewClient(&http.Client{Transport: rewriteTransport{target: target}})
+}
+
+// rewriteTransport sends every request to the test server, keeping path and query.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+	clone.URL.Scheme = t.target.Scheme
+	clone.URL.Host = t.target.Host
+	clone.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(clone)
+}
@@ -1,10 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"sync"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"ingresso-finder-cli/cli"
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/replay"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
 	"ingresso-finder-cli/tui"
 )
 
@@ -16,7 +26,14 @@ var (
 )
 
 func printUsage(out *os.File) {
-	fmt.Fprintf(out, "Usage: %s [--version]\n", appName)
+	fmt.Fprintf(out, "Usage: %s [--version] [--offline | --record DIR | --replay DIR] [--debug-log FILE] [command] [flags]\n\n", appName)
+	fmt.Fprintln(out, "Without a command the interactive finder is started.")
+	fmt.Fprintln(out, "--offline never touches the network and answers from the local caches, however old.")
+	fmt.Fprintln(out, "--record saves every HTTP exchange as fixtures in DIR, except the IP geolocation lookups that reveal")
+	fmt.Fprintln(out, "your location; --replay answers from them offline.")
+	fmt.Fprintln(out, "--debug-log appends every request, cache hit and location attempt to FILE.")
+	fmt.Fprintln(out)
+	cli.PrintCommands(out)
 }
 
 func printVersion() {
@@ -27,10 +44,126 @@ func printVersion() {
 	fmt.Println()
 }
 
+// globalFlags apply to the TUI and to every command.
+type globalFlags struct {
+	recordDir string
+	replayDir string
+	offline   bool
+	debugLog  string
+}
+
+// splitGlobalFlags removes the leading --record, --replay, --offline and
+// --debug-log flags.
+func splitGlobalFlags(args []string) ([]string, globalFlags, error) {
+	var flags globalFlags
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(args[0], "=")
+		if name == "--offline" && !hasValue {
+			flags.offline = true
+			args = args[1:]
+			continue
+		}
+		if name != "--record" && name != "--replay" && name != "--debug-log" {
+			break
+		}
+		if !hasValue {
+			if len(args) < 2 {
+				return nil, globalFlags{}, fmt.Errorf("%s requires a path", name)
+			}
+			value = args[1]
+			args = args[1:]
+		}
+		args = args[1:]
+		switch name {
+		case "--record":
+			flags.recordDir = value
+		case "--replay":
+			flags.replayDir = value
+		default:
+			flags.debugLog = value
+		}
+	}
+	modes := 0
+	for _, set := range []bool{flags.recordDir != "", flags.replayDir != "", flags.offline} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return nil, globalFlags{}, fmt.Errorf("--record, --replay and --offline are mutually exclusive")
+	}
+	return args, flags, nil
+}
+
+// setupTransport installs the offline, recording or replaying transport.
+// Offline mode answers from the user's caches, however old. Record and replay
+// keep the API caches in a throwaway directory, so every lookup goes through
+// the fixtures instead of being answered from the user's cache.
+func setupTransport(flags globalFlags) (func(), error) {
+	if flags.offline {
+		service.SetDefaultTransport(service.OfflineTransport())
+		return func() {}, nil
+	}
+	if flags.recordDir == "" && flags.replayDir == "" {
+		return func() {}, nil
+	}
+	var transport http.RoundTripper
+	if flags.recordDir != "" {
+		recorder, err := replay.NewRecorder(flags.recordDir, nil)
+		if err != nil {
+			return nil, err
+		}
+		// The IP geolocation answers tell where the user is; they are not
+		// worth leaking into fixtures that get shared in bug reports.
+		recorder.Skip(service.LocationHosts()...)
+		transport = recorder
+	} else {
+		player, err := replay.NewPlayer(flags.replayDir)
+		if err != nil {
+			return nil, err
+		}
+		transport = player
+	}
+	cacheDir, err := os.MkdirTemp("", "ingresso-replay-cache-")
+	if err != nil {
+		return nil, err
+	}
+	service.SetDefaultTransport(transport)
+	store.SetCacheDir(cacheDir)
+	return func() { _ = os.RemoveAll(cacheDir) }, nil
+}
+
+// setupDebugLog appends every request event to path, one line each.
+func setupDebugLog(path string) (func(), error) {
+	if path == "" {
+		return func() {}, nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open debug log: %w", err)
+	}
+	var mu sync.Mutex
+	remove := service.AddObserver(func(event service.RequestEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintln(file, event.String())
+	})
+	return func() {
+		remove()
+		_ = file.Close()
+	}, nil
+}
+
 func handleArgs(args []string) bool {
 	if len(args) == 0 {
 		return true
 	}
+	if cli.IsCommand(args[0]) {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		code := cli.Run(ctx, args, os.Stdout, os.Stderr)
+		stop()
+		exit(code)
+	}
 
 	for _, arg := range args {
 		switch arg {
@@ -43,20 +176,61 @@ func handleArgs(args []string) bool {
 		default:
 			fmt.Fprintf(os.Stderr, "Unknown argument: %s\n", arg)
 			printUsage(os.Stderr)
-			os.Exit(2)
+			exit(2)
 		}
 	}
 
 	return false
 }
 
+// cleanup runs before the process exits, including through exit.
+var cleanup = func() {}
+
+func exit(code int) {
+	cleanup()
+	os.Exit(code)
+}
+
 func main() {
-	if !handleArgs(os.Args[1:]) {
+	args, flags, err := splitGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		printUsage(os.Stderr)
+		os.Exit(2)
+	}
+	closeLog, err := setupDebugLog(flags.debugLog)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	resetTransport, err := setupTransport(flags)
+	if err != nil {
+		closeLog()
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	cleanup = func() {
+		resetTransport()
+		closeLog()
+	}
+	defer cleanup()
+
+	if !handleArgs(args) {
 		return
 	}
 
-	if _, err := tea.NewProgram(tui.New(), tea.WithAltScreen()).Run(); err != nil {
+	client, err := finder.NewClient()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		exit(1)
+	}
+	metadata, err := finder.NewMetadataProvider()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		exit(1)
+	}
+	if _, err := tea.NewProgram(tui.New(tui.WithClient(client), tui.WithMetadataProvider(metadata)), tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		exit(1)
 	}
 }
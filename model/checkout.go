@@ -18,6 +18,17 @@ type SessionSection struct {
 	LowestPrice      float64 `json:"lowestPrice"`
 }
 
+// Ticket is a ticket category a section sells, like "Inteira" or
+// "Meia-Entrada". Total is Price plus the Service fee.
+type Ticket struct {
+	Id          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	Service     float64 `json:"service"`
+	Total       float64 `json:"total"`
+}
+
 type SeatMap struct {
 	Id     string     `json:"id"`
 	Bounds SeatBounds `json:"bounds"`
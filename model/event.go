@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// Event is a movie as Ingresso describes it, in the city event listing and
+// on its own details page. Cast and Director are comma separated names.
+type Event struct {
+	Id            string         `json:"id"`
+	Title         string         `json:"title"`
+	OriginalTitle string         `json:"originalTitle"`
+	ContentRating string         `json:"contentRating"`
+	Duration      string         `json:"duration"`
+	Synopsis      string         `json:"synopsis"`
+	Cast          string         `json:"cast"`
+	Director      string         `json:"director"`
+	Distributor   string         `json:"distributor"`
+	CountryOrigin string         `json:"countryOrigin"`
+	Genres        []string       `json:"genres"`
+	Trailers      []EventTrailer `json:"trailers"`
+	Images        []EventImage   `json:"images"`
+	SiteURL       string         `json:"siteURL"`
+	PremiereDate  struct {
+		LocalDate time.Time `json:"localDate"`
+	} `json:"premiereDate"`
+}
+
+// EventTrailer is a trailer link, usually on YouTube.
+type EventTrailer struct {
+	Type        string `json:"type"`
+	Url         string `json:"url"`
+	EmbeddedUrl string `json:"embeddedUrl"`
+}
+
+// EventImage is a poster or banner; Type is "PosterPortrait" or "PosterHorizontal".
+type EventImage struct {
+	Url  string `json:"url"`
+	Type string `json:"type"`
+}
+
+// EventSessionDay is one day of an event's schedule across the theaters of a city.
+type EventSessionDay struct {
+	Date          string         `json:"date"`
+	DateFormatted string         `json:"dateFormatted"`
+	DayOfWeek     string         `json:"dayOfWeek"`
+	IsToday       bool           `json:"isToday"`
+	Theaters      []EventTheater `json:"theaters"`
+}
+
+// EventTheater is a theater showing an event, with the rooms and sessions it
+// has for it.
+type EventTheater struct {
+	Theater
+	Rooms []TheaterRoom `json:"rooms"`
+}
@@ -8,6 +8,7 @@ type Theater struct {
 	Address      string `json:"address"`
 	Neighborhood string `json:"neighborhood"`
 	City         string `json:"city"`
+	CityId       string `json:"cityId"`
 	Uf           string `json:"uf"`
 	UrlKey       string `json:"urlKey"`
 	Geolocation  struct {
@@ -0,0 +1,305 @@
+// Package render draws session data as text, shared by the TUI and the
+// headless commands.
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SeatMapOptions controls how SeatMap draws a seat map.
+type SeatMapOptions struct {
+	// ShowNumbers replaces the seat tokens with the seat numbers.
+	ShowNumbers bool
+	// NoColor disables every ANSI style.
+	NoColor bool
+	// ASCII restricts the output to ASCII characters, for pipes and plain-text chats.
+	ASCII bool
+}
+
+// SeatMap draws the seat grid, the screen marker, the legend and the seat counts.
+func SeatMap(seatMap model.SeatMap, opts SeatMapOptions) string {
+	if seatMap.Bounds.Lines == 0 || seatMap.Bounds.Columns == 0 {
+		return "No seat map data."
+	}
+
+	rows := seatMap.Bounds.Lines
+	cols := seatMap.Bounds.Columns
+
+	grid := make([][]seatCell, rows)
+	for i := range grid {
+		grid[i] = make([]seatCell, cols)
+	}
+
+	rowLabel := make(map[int]string)
+	frontRows := finder.FrontLines(seatMap, finder.FrontRowCount)
+	placed := 0
+	minRow, maxRow := rows-1, 0
+	minCol, maxCol := cols-1, 0
+
+	for _, line := range seatMap.Lines {
+		for _, seat := range line.Seats {
+			r := seat.Line - 1
+			c := seat.Column - 1
+			if r < 0 || c < 0 || r >= rows || c >= cols {
+				continue
+			}
+			placed++
+			minRow = min(minRow, r)
+			maxRow = max(maxRow, r)
+			minCol = min(minCol, c)
+			maxCol = max(maxCol, c)
+
+			if _, ok := rowLabel[r]; !ok {
+				rowLabel[r] = seatRowLabel(seat)
+			}
+			token, status := seatToken(seat)
+			grid[r][c] = seatCell{
+				token:  token,
+				status: status,
+				label:  seatNumberLabel(seat),
+				front:  frontRows[seat.Line],
+			}
+		}
+	}
+
+	if placed == 0 {
+		return "No seat map data."
+	}
+
+	rowWidth := 2
+	for _, label := range rowLabel {
+		if len(label) > rowWidth {
+			rowWidth = len(label)
+		}
+	}
+
+	maxLabelWidth := 2
+	if opts.ShowNumbers {
+		for r := minRow; r <= maxRow; r++ {
+			for c := minCol; c <= maxCol; c++ {
+				if l := len(grid[r][c].label); l > maxLabelWidth {
+					maxLabelWidth = l
+				}
+			}
+		}
+	}
+	cellWidth := max(2, maxLabelWidth)
+
+	paint := func(style lipgloss.Style, text string) string {
+		if opts.NoColor {
+			return text
+		}
+		return style.Render(text)
+	}
+
+	var b strings.Builder
+	seatStyleAvailable := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	seatStyleOccupied := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	seatStyleBlocked := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	seatStyleAccessible := lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	seatStyleFront := lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
+
+	for r := minRow; r <= maxRow; r++ {
+		label := rowLabel[r]
+		if label == "" {
+			label = fmt.Sprintf("%d", r+1)
+		}
+		b.WriteString(fmt.Sprintf("%*s ", rowWidth, label))
+		for c := minCol; c <= maxCol; c++ {
+			cell := grid[r][c]
+			text := cell.token
+			if opts.ShowNumbers && cell.label != "" {
+				text = cell.label
+			}
+			rendered := padCell(text, cellWidth)
+			switch cell.token {
+			case "[]":
+				if cell.front {
+					rendered = paint(seatStyleFront, rendered)
+				} else {
+					rendered = paint(seatStyleAvailable, rendered)
+				}
+			case "XX":
+				rendered = paint(seatStyleOccupied, rendered)
+			case "##":
+				rendered = paint(seatStyleBlocked, rendered)
+			case "DD":
+				rendered = paint(seatStyleAccessible, rendered)
+			}
+			b.WriteString(rendered)
+			if c < maxCol {
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString(fmt.Sprintf(" %*s\n", rowWidth, label))
+	}
+
+	gridWidth := (maxCol-minCol+1)*(cellWidth+1) - 1
+	screenStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("0")).
+		Background(lipgloss.Color("214"))
+	screenBorderStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Background(lipgloss.Color("236"))
+
+	screenBar := screenBarBlock(gridWidth, "SCREEN", opts.ASCII)
+	faint := lipgloss.NewStyle().Faint(true)
+
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat(" ", rowWidth+1))
+	b.WriteString(paint(screenBorderStyle, screenBar.top))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat(" ", rowWidth+1))
+	b.WriteString(paint(screenStyle, screenBar.mid))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat(" ", rowWidth+1))
+	b.WriteString(paint(screenBorderStyle, screenBar.bot))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat(" ", rowWidth+1))
+	b.WriteString(paint(faint, "Front / Screen"))
+	b.WriteString("\n\n")
+
+	// Styles for Legend
+	availStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))   // Green
+	occStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))    // Red
+	accessStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")) // Orange
+	blockStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))  // Grey
+	frontStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))  // Yellow
+
+	legendParts := []string{
+		paint(availStyle, "● Livre"),
+		paint(occStyle, "✖ Ocupado"),
+		paint(accessStyle, "♿ Acessível"),
+		paint(blockStyle, "◼ Bloqueado"),
+		paint(frontStyle, "▼ Frente"),
+	}
+	if opts.ASCII {
+		legendParts = []string{"[] Livre", "XX Ocupado", "DD Acessivel", "## Bloqueado"}
+	}
+	legend := strings.Join(legendParts, "  ")
+
+	if opts.ShowNumbers {
+		if opts.ASCII {
+			legend += "  (Numeros ativados)"
+		} else {
+			legend += "  " + paint(faint, "(Números ativados)")
+		}
+	}
+
+	summary := finder.CountSeats(seatMap)
+	percent := float64(summary.Available) / float64(max(1, summary.Total)) * 100
+
+	counts := fmt.Sprintf(
+		"Disponíveis: %d (%d ideais)  •  Duplas: %d  •  Total: %d (%.0f%%)",
+		summary.Available, summary.IdealAvailable, summary.PairAvailable, summary.Total, percent,
+	)
+	if opts.ASCII {
+		counts = fmt.Sprintf(
+			"Disponiveis: %d (%d ideais)  -  Duplas: %d  -  Total: %d (%.0f%%)",
+			summary.Available, summary.IdealAvailable, summary.PairAvailable, summary.Total, percent,
+		)
+	}
+
+	return b.String() + "\n" + legend + "\n" + paint(faint, counts)
+}
+
+func seatToken(seat model.Seat) (string, string) {
+	switch strings.ToLower(seat.Status) {
+	case "occupied":
+		return "XX", "occupied"
+	case "available":
+		if strings.EqualFold(seat.Type, "Disability") {
+			return "DD", "available"
+		}
+		return "[]", "available"
+	case "blocked", "unavailable":
+		return "##", "blocked"
+	default:
+		return "  ", "unknown"
+	}
+}
+
+type seatCell struct {
+	token  string
+	status string
+	label  string
+	front  bool
+}
+
+func seatRowLabel(seat model.Seat) string {
+	parts := strings.Fields(strings.TrimSpace(seat.Label))
+	if len(parts) > 0 {
+		first := parts[0]
+		if len(first) == 1 && first[0] >= 'A' && first[0] <= 'Z' {
+			return first
+		}
+	}
+	if seat.Line > 0 {
+		return fmt.Sprintf("%d", seat.Line)
+	}
+	return ""
+}
+
+func seatNumberLabel(seat model.Seat) string {
+	parts := strings.Fields(strings.TrimSpace(seat.Label))
+	if len(parts) == 0 {
+		return ""
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return parts[len(parts)-1]
+}
+
+func padCell(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if text == "" {
+		return strings.Repeat(" ", width)
+	}
+	if len(text) >= width {
+		return text[:width]
+	}
+	padding := width - len(text)
+	left := padding / 2
+	right := padding - left
+	return strings.Repeat(" ", left) + text + strings.Repeat(" ", right)
+}
+
+type screenBlock struct {
+	top string
+	mid string
+	bot string
+}
+
+func screenBarBlock(width int, label string, ascii bool) screenBlock {
+	if width < len(label)+4 {
+		width = len(label) + 4
+	}
+	if width < 10 {
+		width = 10
+	}
+
+	corner, horizontal, vertical := [4]string{"╭", "╮", "╰", "╯"}, "─", "│"
+	if ascii {
+		corner, horizontal, vertical = [4]string{"+", "+", "+", "+"}, "-", "|"
+	}
+
+	border := corner[0] + strings.Repeat(horizontal, width-2) + corner[1]
+	bottom := corner[2] + strings.Repeat(horizontal, width-2) + corner[3]
+
+	labelText := " " + label + " "
+	padding := width - len(labelText) - 2
+	left := padding / 2
+	right := padding - left
+	mid := vertical + strings.Repeat(" ", left) + labelText + strings.Repeat(" ", right) + vertical
+	return screenBlock{top: border, mid: mid, bot: bottom}
+}
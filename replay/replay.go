@@ -0,0 +1,237 @@
+// Package replay records HTTP exchanges as JSON fixtures and serves them back,
+// for offline demos and reproducible bug reports.
+//
+// Every request gets a key made of its method and URL, with credentials such
+// as the OMDb apikey removed. Repeated requests are numbered, so a replay
+// answers them in the order they were recorded and then keeps repeating the
+// last answer.
+package replay
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// redactedParams are query parameters left out of fixtures and request keys.
+var redactedParams = []string{"apikey", "api_key", "key", "token"}
+
+// Fixture is one recorded request/response pair.
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+// FixtureRequest identifies the recorded request.
+type FixtureRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// FixtureResponse is the recorded response. Body is stored as text unless it
+// is not valid UTF-8, in which case BodyEncoding is "base64".
+type FixtureResponse struct {
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that saves every exchange to a directory,
+// except those with the hosts given to Skip.
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+	skip map[string]bool
+
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+// NewRecorder creates dir if needed and records the exchanges sent through next.
+// If next is nil, http.DefaultTransport is used.
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create fixture directory: %w", err)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, next: next, skip: map[string]bool{}, seen: map[string]int{}}, nil
+}
+
+// Skip sends the requests to hosts without saving them, for answers that must
+// not end up in fixtures. Call it before the first request.
+func (r *Recorder) Skip(hosts ...string) {
+	for _, host := range hosts {
+		r.skip[strings.ToLower(host)] = true
+	}
+}
+
+// RoundTrip implements http.RoundTripper. Network errors are returned as is
+// and not recorded.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.skip[strings.ToLower(req.URL.Hostname())] {
+		return r.next.RoundTrip(req)
+	}
+	res, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	key := requestKey(req)
+	r.mu.Lock()
+	r.seen[key]++
+	n := r.seen[key]
+	r.mu.Unlock()
+
+	header := res.Header.Clone()
+	header.Del("Set-Cookie")
+	fixture := Fixture{
+		Request:  FixtureRequest{Method: req.Method, URL: redactURL(req.URL)},
+		Response: FixtureResponse{StatusCode: res.StatusCode, Header: header},
+	}
+	if utf8.Valid(body) {
+		fixture.Response.Body = string(body)
+	} else {
+		fixture.Response.Body = base64.StdEncoding.EncodeToString(body)
+		fixture.Response.BodyEncoding = "base64"
+	}
+	payload, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, fixtureName(req, key, n)), payload, 0o644); err != nil {
+		return nil, fmt.Errorf("write fixture: %w", err)
+	}
+	return res, nil
+}
+
+// Player is an http.RoundTripper that answers from the fixtures in a directory.
+type Player struct {
+	dir string
+
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+// NewPlayer serves the fixtures recorded in dir.
+func NewPlayer(dir string) (*Player, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("open fixture directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("open fixture directory: %s is not a directory", dir)
+	}
+	return &Player{dir: dir, seen: map[string]int{}}, nil
+}
+
+// RoundTrip implements http.RoundTripper. Requests without a fixture fail
+// with an error naming the missing request.
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := requestKey(req)
+	p.mu.Lock()
+	n := p.seen[key] + 1
+	fixture, err := p.load(req, key, n)
+	if err == nil {
+		p.seen[key] = n
+	} else if errors.Is(err, os.ErrNotExist) && n > 1 {
+		fixture, err = p.load(req, key, n-1)
+	}
+	p.mu.Unlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, redactURL(req.URL))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	body := []byte(fixture.Response.Body)
+	if fixture.Response.BodyEncoding == "base64" {
+		if body, err = base64.StdEncoding.DecodeString(fixture.Response.Body); err != nil {
+			return nil, fmt.Errorf("decode fixture body: %w", err)
+		}
+	}
+	header := fixture.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Response.StatusCode, http.StatusText(fixture.Response.StatusCode)),
+		StatusCode:    fixture.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (p *Player) load(req *http.Request, key string, n int) (Fixture, error) {
+	var fixture Fixture
+	data, err := os.ReadFile(filepath.Join(p.dir, fixtureName(req, key, n)))
+	if err != nil {
+		return fixture, err
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return fixture, fmt.Errorf("parse fixture: %w", err)
+	}
+	return fixture, nil
+}
+
+func requestKey(req *http.Request) string {
+	return req.Method + " " + redactURL(req.URL)
+}
+
+// redactURL drops credentials from the query and sorts it, so the same
+// request always produces the same key.
+func redactURL(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+	query := clean.Query()
+	for _, name := range redactedParams {
+		query.Del(name)
+	}
+	clean.RawQuery = query.Encode()
+	clean.Fragment = ""
+	return clean.String()
+}
+
+// fixtureName builds a readable, filesystem-safe name such as
+// api-content.ingresso.com_v0_states_1a2b3c4d_001.json.
+func fixtureName(req *http.Request, key string, n int) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, req.URL.Host+req.URL.Path)
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("%s_%s_%03d.json", slug, hex.EncodeToString(sum[:4]), n)
+}
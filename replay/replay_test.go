@@ -0,0 +1,127 @@
+package replay
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	res, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return res.StatusCode, string(body)
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = io.WriteString(w, `{"poll":`+string('0'+n)+`}`)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	recording := &http.Client{Transport: recorder}
+	get(t, recording, server.URL+"/seats?apikey=abc&t=Duna")
+	get(t, recording, server.URL+"/seats?t=Duna&apikey=other")
+	if status, _ := get(t, recording, server.URL+"/missing"); status != http.StatusNotFound {
+		t.Fatalf("expected the 404 to pass through, got %d", status)
+	}
+	server.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("expected 3 fixtures, got %d (%v)", len(entries), err)
+	}
+	for _, entry := range entries {
+		data, _ := os.ReadFile(dir + "/" + entry.Name())
+		if strings.Contains(string(data), "abc") || strings.Contains(string(data), "secret") {
+			t.Fatalf("expected credentials to be redacted from %s:\n%s", entry.Name(), data)
+		}
+	}
+
+	player, err := NewPlayer(dir)
+	if err != nil {
+		t.Fatalf("new player: %v", err)
+	}
+	replaying := &http.Client{Transport: player}
+	want := []string{`{"poll":1}`, `{"poll":2}`, `{"poll":2}`}
+	for i, body := range want {
+		if _, got := get(t, replaying, server.URL+"/seats?t=Duna&apikey=xyz"); got != body {
+			t.Fatalf("replay %d: expected %s, got %s", i, body, got)
+		}
+	}
+	if status, _ := get(t, replaying, server.URL+"/missing"); status != http.StatusNotFound {
+		t.Fatalf("expected the recorded 404, got %d", status)
+	}
+	if _, err := replaying.Get(server.URL + "/unknown"); err == nil || !strings.Contains(err.Error(), "no recorded response for GET") {
+		t.Fatalf("expected a missing fixture error, got %v", err)
+	}
+}
+
+func TestBinaryBodies(t *testing.T) {
+	payload := string([]byte{0xff, 0x00, 0xfe})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, payload)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	get(t, &http.Client{Transport: recorder}, server.URL+"/poster.jpg")
+
+	player, err := NewPlayer(dir)
+	if err != nil {
+		t.Fatalf("new player: %v", err)
+	}
+	if _, got := get(t, &http.Client{Transport: player}, server.URL+"/poster.jpg"); got != payload {
+		t.Fatalf("expected the binary body back, got %q", got)
+	}
+}
+
+func TestSkippedHostsAreNotRecorded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"city":"São Paulo"}`)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	target, _ := url.Parse(server.URL)
+	recorder.Skip(target.Hostname())
+	if _, body := get(t, &http.Client{Transport: recorder}, server.URL+"/json"); body != `{"city":"São Paulo"}` {
+		t.Fatalf("expected the answer to pass through, got %s", body)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("expected no fixtures for a skipped host, got %d", len(entries))
+	}
+}
@@ -0,0 +1,322 @@
+// Package rpc serves the finder over line-delimited JSON-RPC 2.0, one request
+// and one response per line, for editor plugins and assistant tools.
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+)
+
+// Standard JSON-RPC 2.0 error codes, plus codeUpstream for Ingresso failures.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeUpstream       = -32000
+	codeNotFound       = -32001
+)
+
+const maxLineSize = 1 << 20
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+func invalidParams(format string, args ...any) *Error {
+	return &Error{Code: codeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+type handler func(ctx context.Context, s *Server, params json.RawMessage) (any, error)
+
+// Server answers JSON-RPC requests using a Finder.
+type Server struct {
+	finder *finder.Finder
+}
+
+// New creates a Server backed by f. If f is nil, a default Finder is used.
+func New(f *finder.Finder) *Server {
+	if f == nil {
+		f = finder.New(nil)
+	}
+	return &Server{finder: f}
+}
+
+// Serve reads requests from r until EOF or until ctx is canceled and writes
+// one response line per request to w. Notifications get no response.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		resp, ok := s.handleLine(ctx, line)
+		if !ok {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// handleLine decodes and runs one request. It reports false for notifications.
+func (s *Server) handleLine(ctx context.Context, line []byte) (response, bool) {
+	resp := response{JSONRPC: "2.0", ID: json.RawMessage("null")}
+	if line[0] == '[' {
+		resp.Error = &Error{Code: codeInvalidRequest, Message: "batch requests are not supported"}
+		return resp, true
+	}
+
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		resp.Error = &Error{Code: codeParseError, Message: err.Error()}
+		return resp, true
+	}
+	notification := len(req.ID) == 0
+	if !notification {
+		resp.ID = req.ID
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &Error{Code: codeInvalidRequest, Message: `expected "jsonrpc": "2.0" and a method`}
+		return resp, !notification
+	}
+
+	method, ok := methods[req.Method]
+	if !ok {
+		resp.Error = &Error{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+		return resp, !notification
+	}
+	result, err := method(ctx, s, req.Params)
+	if err == nil {
+		resp.Result, err = json.Marshal(result)
+	}
+	if err != nil {
+		resp.Error = toError(err)
+	}
+	return resp, !notification
+}
+
+func toError(err error) *Error {
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	if service.IsNotFound(err) {
+		return &Error{Code: codeNotFound, Message: err.Error()}
+	}
+	return &Error{Code: codeUpstream, Message: err.Error()}
+}
+
+// decodeParams unmarshals params into out, rejecting unknown fields.
+func decodeParams(params json.RawMessage, out any) error {
+	if len(params) == 0 || string(params) == "null" {
+		params = json.RawMessage("{}")
+	}
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return invalidParams("invalid params: %v", err)
+	}
+	return nil
+}
+
+func required(name, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return invalidParams("%s is required", name)
+	}
+	return nil
+}
+
+// parseDate reads an optional YYYY-MM-DD date, defaulting to today.
+func parseDate(value string) (time.Time, error) {
+	date, err := finder.ParseDate(value)
+	if err != nil {
+		return time.Time{}, invalidParams("invalid date %q: %v", value, err)
+	}
+	return date, nil
+}
+
+// parseNear reads an optional "lat,lng" reference point.
+func parseNear(value string) (*service.UserLocation, error) {
+	location, err := finder.ParseNear(value)
+	if err != nil {
+		return nil, invalidParams("invalid near %q: %v", value, err)
+	}
+	return location, nil
+}
+
+type cityNameParams struct {
+	CityName string `json:"cityName"`
+}
+
+type cityParams struct {
+	CityID string `json:"cityId"`
+}
+
+type sessionsParams struct {
+	CityID    string `json:"cityId"`
+	TheaterID string `json:"theaterId"`
+	Date      string `json:"date"`
+}
+
+type sessionParams struct {
+	SessionID string `json:"sessionId"`
+}
+
+type seatMapParams struct {
+	SessionID string `json:"sessionId"`
+	SectionID string `json:"sectionId"`
+}
+
+type catalogParams struct {
+	CityID        string `json:"cityId"`
+	Date          string `json:"date"`
+	Near          string `json:"near"`
+	IncludeHidden bool   `json:"includeHidden"`
+}
+
+// methods mirrors the service.Client lookups, going through the finder caches
+// where the TUI does, plus the catalog aggregation and seat counting.
+var methods = map[string]handler{
+	"getCities": func(ctx context.Context, s *Server, params json.RawMessage) (any, error) {
+		if err := decodeParams(params, &struct{}{}); err != nil {
+			return nil, err
+		}
+		return s.finder.Cities(ctx)
+	},
+	"getCityInfoByName": func(ctx context.Context, s *Server, params json.RawMessage) (any, error) {
+		var p cityNameParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if err := required("cityName", p.CityName); err != nil {
+			return nil, err
+		}
+		return s.finder.CityByName(ctx, p.CityName)
+	},
+	"getTheatersByCity": func(ctx context.Context, s *Server, params json.RawMessage) (any, error) {
+		var p cityParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if err := required("cityId", p.CityID); err != nil {
+			return nil, err
+		}
+		return s.finder.Theaters(ctx, p.CityID)
+	},
+	"getSessionsByCityAndTheater": func(ctx context.Context, s *Server, params json.RawMessage) (any, error) {
+		var p sessionsParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if err := required("cityId", p.CityID); err != nil {
+			return nil, err
+		}
+		if err := required("theaterId", p.TheaterID); err != nil {
+			return nil, err
+		}
+		date, err := parseDate(p.Date)
+		if err != nil {
+			return nil, err
+		}
+		return s.finder.Sessions(ctx, p.CityID, p.TheaterID, date)
+	},
+	"getSessionDetails": func(ctx context.Context, s *Server, params json.RawMessage) (any, error) {
+		var p sessionParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if err := required("sessionId", p.SessionID); err != nil {
+			return nil, err
+		}
+		return s.finder.Client().GetSessionDetails(ctx, p.SessionID)
+	},
+	"getSeatMap": func(ctx context.Context, s *Server, params json.RawMessage) (any, error) {
+		var p seatMapParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if err := required("sessionId", p.SessionID); err != nil {
+			return nil, err
+		}
+		if err := required("sectionId", p.SectionID); err != nil {
+			return nil, err
+		}
+		return s.finder.Client().GetSeatMap(ctx, p.SessionID, p.SectionID)
+	},
+	"countSeats": func(ctx context.Context, s *Server, params json.RawMessage) (any, error) {
+		var p seatMapParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if err := required("sessionId", p.SessionID); err != nil {
+			return nil, err
+		}
+		if err := required("sectionId", p.SectionID); err != nil {
+			return nil, err
+		}
+		seatMap, err := s.finder.Client().GetSeatMap(ctx, p.SessionID, p.SectionID)
+		if err != nil {
+			return nil, err
+		}
+		return finder.CountSeats(seatMap), nil
+	},
+	"getMovieCatalog": func(ctx context.Context, s *Server, params json.RawMessage) (any, error) {
+		var p catalogParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if err := required("cityId", p.CityID); err != nil {
+			return nil, err
+		}
+		date, err := parseDate(p.Date)
+		if err != nil {
+			return nil, err
+		}
+		location, err := parseNear(p.Near)
+		if err != nil {
+			return nil, err
+		}
+		return s.finder.CitiesCatalog(ctx, []model.City{{Id: p.CityID}}, date, location, p.IncludeHidden)
+	},
+}
@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/internal/ingressotest"
+)
+
+type testResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+func serveLines(t *testing.T, lines ...string) []testResponse {
+	t.Helper()
+	ingressotest.IsolateStore(t)
+
+	client := ingressotest.NewClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v0/states":
+			_, _ = w.Write([]byte(`[{"uf":"SP","cities":[{"id":"1","name":"Sao Paulo","uf":"SP"}]}]`))
+		case "/v1/sessions/s1/sections/sec1/seats":
+			_, _ = w.Write([]byte(`{"lines":[{"line":1,"seats":[{"status":"Available","line":1,"column":1},{"status":"Available","line":1,"column":2}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	var out strings.Builder
+	in := strings.NewReader(strings.Join(lines, "\n") + "\n")
+	if err := New(finder.New(client)).Serve(context.Background(), in, &out); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+
+	var responses []testResponse
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var resp testResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestServe_MethodsAndNotifications(t *testing.T) {
+	responses := serveLines(t,
+		`{"jsonrpc":"2.0","id":1,"method":"getCities"}`,
+		`{"jsonrpc":"2.0","method":"getCities"}`,
+		`{"jsonrpc":"2.0","id":"seats","method":"countSeats","params":{"sessionId":"s1","sectionId":"sec1"}}`,
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected no response for the notification, got %d responses", len(responses))
+	}
+	if string(responses[0].ID) != "1" || !strings.Contains(string(responses[0].Result), `"Sao Paulo"`) {
+		t.Fatalf("unexpected getCities response: %+v", responses[0])
+	}
+	var summary finder.SeatSummary
+	if err := json.Unmarshal(responses[1].Result, &summary); err != nil {
+		t.Fatalf("decode summary: %v", err)
+	}
+	if string(responses[1].ID) != `"seats"` || summary.Available != 2 || summary.PairAvailable != 1 {
+		t.Fatalf("unexpected countSeats response: %+v (%+v)", responses[1], summary)
+	}
+}
+
+func TestServe_Errors(t *testing.T) {
+	responses := serveLines(t,
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"nope"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"getSeatMap","params":{"sessionId":"s1"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"getSessionDetails","params":{"sessionId":"missing"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"getCities","params":{"bogus":true}}`,
+	)
+
+	want := []int{codeParseError, codeMethodNotFound, codeInvalidParams, codeNotFound, codeInvalidParams}
+	if len(responses) != len(want) {
+		t.Fatalf("expected %d responses, got %d", len(want), len(responses))
+	}
+	for i, resp := range responses {
+		if resp.Error == nil || resp.Error.Code != want[i] {
+			t.Fatalf("response %d: expected error code %d, got %+v", i, want[i], resp)
+		}
+		if resp.Result != nil {
+			t.Fatalf("response %d: expected no result alongside an error", i)
+		}
+	}
+	if string(responses[0].ID) != "null" {
+		t.Fatalf("expected a null id for parse errors, got %s", responses[0].ID)
+	}
+}
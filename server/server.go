@@ -0,0 +1,239 @@
+// Package server exposes the finder lookups as a local JSON API, so several
+// consumers can share one cached client instead of each calling Ingresso.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+)
+
+// Server answers the /api routes using a Finder.
+type Server struct {
+	finder *finder.Finder
+	mux    *http.ServeMux
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type theaterResponse struct {
+	model.Theater
+	DistanceKM *float64 `json:"distanceKm,omitempty"`
+	Hidden     bool     `json:"hidden,omitempty"`
+}
+
+type seatMapResponse struct {
+	SeatMap model.SeatMap      `json:"seatMap"`
+	Summary finder.SeatSummary `json:"summary"`
+}
+
+// badRequest marks errors caused by the query string rather than the upstream API.
+type badRequest struct {
+	msg string
+}
+
+func (e badRequest) Error() string {
+	return e.msg
+}
+
+// New creates a Server backed by f. If f is nil, a default Finder is used.
+func New(f *finder.Finder) *Server {
+	if f == nil {
+		f = finder.New(nil)
+	}
+	s := &Server{finder: f, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /api/cities", s.handleCities)
+	s.mux.HandleFunc("GET /api/cities/{cityID}/theaters", s.handleTheaters)
+	s.mux.HandleFunc("GET /api/cities/{cityID}/theaters/{theaterID}/sessions", s.handleSessions)
+	s.mux.HandleFunc("GET /api/cities/{cityID}/movies", s.handleCatalog)
+	s.mux.HandleFunc("GET /api/sessions/{sessionID}", s.handleSessionDetails)
+	s.mux.HandleFunc("GET /api/sessions/{sessionID}/sections/{sectionID}/seats", s.handleSeatMap)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleCities(w http.ResponseWriter, r *http.Request) {
+	cities, err := s.finder.Cities(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if uf := strings.TrimSpace(r.URL.Query().Get("uf")); uf != "" {
+		filtered := []model.City{}
+		for _, city := range cities {
+			if strings.EqualFold(city.Uf, uf) {
+				filtered = append(filtered, city)
+			}
+		}
+		cities = filtered
+	}
+	writeJSON(w, http.StatusOK, cities)
+}
+
+// handleTheaters lists the visible theaters of a city. includeHidden=true adds
+// the theaters hidden in the TUI and near=lat,lng sorts them by distance.
+func (s *Server) handleTheaters(w http.ResponseWriter, r *http.Request) {
+	cityID := r.PathValue("cityID")
+	location, err := queryLocation(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	includeHidden, err := queryBool(r, "includeHidden")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	theaters, err := s.finder.Theaters(r.Context(), cityID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	hidden, err := store.LoadHiddenTheaters(cityID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !includeHidden {
+		theaters = finder.VisibleTheaters(theaters, hidden)
+	}
+	finder.SortTheatersByDistance(theaters, location)
+
+	out := make([]theaterResponse, 0, len(theaters))
+	for _, theater := range theaters {
+		entry := theaterResponse{Theater: theater, Hidden: hidden[theater.Id]}
+		if distance, ok := finder.TheaterDistanceKM(theater, location); ok {
+			entry.DistanceKM = &distance
+		}
+		out = append(out, entry)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// handleSessions returns the schedule of one theater for the requested day.
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	date, err := queryDate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	days, err := s.finder.Sessions(r.Context(), r.PathValue("cityID"), r.PathValue("theaterID"), date)
+	if err != nil && !service.IsNotFound(err) {
+		writeError(w, err)
+		return
+	}
+	// Another day of the schedule is not an answer for date; a date without
+	// sessions gets an empty list.
+	day, ok := finder.FindDay(days, date)
+	if !ok {
+		day = model.TheaterSessionDay{Date: date.Format(time.DateOnly)}
+	}
+	if day.Movies == nil {
+		day.Movies = []model.TheaterMovie{}
+	}
+	writeJSON(w, http.StatusOK, day)
+}
+
+// handleCatalog merges the movies of every visible theater of a city, like the
+// find command and the "movie across theaters" mode of the TUI.
+func (s *Server) handleCatalog(w http.ResponseWriter, r *http.Request) {
+	cityID := r.PathValue("cityID")
+	date, err := queryDate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	location, err := queryLocation(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	catalog, err := s.finder.CitiesCatalog(r.Context(), []model.City{{Id: cityID}}, date, location, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, catalog)
+}
+
+func (s *Server) handleSessionDetails(w http.ResponseWriter, r *http.Request) {
+	detail, err := s.finder.Client().GetSessionDetails(r.Context(), r.PathValue("sessionID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+func (s *Server) handleSeatMap(w http.ResponseWriter, r *http.Request) {
+	seatMap, err := s.finder.Client().GetSeatMap(r.Context(), r.PathValue("sessionID"), r.PathValue("sectionID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, seatMapResponse{SeatMap: seatMap, Summary: finder.CountSeats(seatMap)})
+}
+
+func queryDate(r *http.Request) (time.Time, error) {
+	date, err := finder.ParseDate(r.URL.Query().Get("date"))
+	if err != nil {
+		return time.Time{}, badRequest{msg: "invalid date: " + err.Error()}
+	}
+	return date, nil
+}
+
+func queryLocation(r *http.Request) (*service.UserLocation, error) {
+	location, err := finder.ParseNear(r.URL.Query().Get("near"))
+	if err != nil {
+		return nil, badRequest{msg: "invalid near: " + err.Error()}
+	}
+	return location, nil
+}
+
+func queryBool(r *http.Request, name string) (bool, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, badRequest{msg: "invalid " + name}
+	}
+	return parsed, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError maps query errors to 400, upstream 404s to 404 and everything
+// else to 502, since the failure happened while talking to Ingresso.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	var bad badRequest
+	switch {
+	case errors.As(err, &bad):
+		status = http.StatusBadRequest
+	case service.IsNotFound(err):
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
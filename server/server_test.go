@@ -0,0 +1,116 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/internal/ingressotest"
+	"ingresso-finder-cli/model"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	ingressotest.IsolateStore(t)
+
+	client := ingressotest.NewClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v0/states":
+			_, _ = w.Write([]byte(`[{"uf":"SP","cities":[{"id":"1","name":"Sao Paulo","uf":"SP"}]},{"uf":"RJ","cities":[{"id":"2","name":"Rio de Janeiro","uf":"RJ"}]}]`))
+		case "/v0/theaters/city/1":
+			_, _ = w.Write([]byte(`[{"id":"10","name":"Longe","geolocation":{"lat":-23.0,"lng":-46.0}},{"id":"11","name":"Perto","geolocation":{"lat":-23.55,"lng":-46.63}}]`))
+		case "/v0/sessions/city/1/theater/10":
+			_, _ = w.Write([]byte(`[{"date":"2026-10-20","movies":[{"id":"m1","title":"Duna","rooms":[{"name":"Sala 1","sessions":[{"id":"s1","date":{"localDate":"2026-10-20T18:30:00-03:00"}}]}]}]}]`))
+		case "/v0/sessions/city/1/theater/11":
+			http.NotFound(w, r)
+		case "/v1/sessions/s1/sections/sec1/seats":
+			_, _ = w.Write([]byte(`{"lines":[{"line":1,"seats":[{"status":"Available","line":1,"column":1},{"status":"Occupied","line":1,"column":2}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	srv := httptest.NewServer(New(finder.New(client)))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getJSON(t *testing.T, srv *httptest.Server, path string, wantStatus int, out any) {
+	t.Helper()
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("GET %s: expected status %d, got %d", path, wantStatus, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("GET %s: decode: %v", path, err)
+	}
+}
+
+func TestCitiesFilteredByUF(t *testing.T) {
+	srv := newTestServer(t)
+
+	var cities []model.City
+	getJSON(t, srv, "/api/cities?uf=rj", http.StatusOK, &cities)
+	if len(cities) != 1 || cities[0].Id != "2" {
+		t.Fatalf("unexpected cities: %+v", cities)
+	}
+}
+
+func TestTheatersSortedByDistance(t *testing.T) {
+	srv := newTestServer(t)
+
+	var theaters []theaterResponse
+	getJSON(t, srv, "/api/cities/1/theaters?near=-23.55,-46.63", http.StatusOK, &theaters)
+	if len(theaters) != 2 || theaters[0].Id != "11" || theaters[0].DistanceKM == nil {
+		t.Fatalf("expected the nearest theater first, got %+v", theaters)
+	}
+}
+
+func TestSessionsOnlyForTheRequestedDate(t *testing.T) {
+	srv := newTestServer(t)
+
+	var day model.TheaterSessionDay
+	getJSON(t, srv, "/api/cities/1/theaters/10/sessions?date=2026-10-20", http.StatusOK, &day)
+	if day.Date != "2026-10-20" || len(day.Movies) != 1 {
+		t.Fatalf("unexpected sessions: %+v", day)
+	}
+
+	day = model.TheaterSessionDay{}
+	getJSON(t, srv, "/api/cities/1/theaters/10/sessions?date=2026-10-21", http.StatusOK, &day)
+	if day.Date != "2026-10-21" || day.Movies == nil || len(day.Movies) != 0 {
+		t.Fatalf("expected no sessions on a day without a schedule, got %+v", day)
+	}
+}
+
+func TestCatalogAndSeatMap(t *testing.T) {
+	srv := newTestServer(t)
+
+	var catalog finder.Catalog
+	getJSON(t, srv, "/api/cities/1/movies?date=2026-10-20", http.StatusOK, &catalog)
+	if len(catalog.Movies) != 1 || catalog.Movies[0].Movie.Title != "Duna" || catalog.Ignored != 1 {
+		t.Fatalf("unexpected catalog: %+v", catalog)
+	}
+
+	var seats seatMapResponse
+	getJSON(t, srv, "/api/sessions/s1/sections/sec1/seats", http.StatusOK, &seats)
+	if seats.Summary.Available != 1 || seats.Summary.Occupied != 1 {
+		t.Fatalf("unexpected summary: %+v", seats.Summary)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv := newTestServer(t)
+
+	var body errorResponse
+	getJSON(t, srv, "/api/cities/1/movies?date=tomorrow", http.StatusBadRequest, &body)
+	if body.Error == "" {
+		t.Fatal("expected an error message")
+	}
+	getJSON(t, srv, "/api/sessions/missing", http.StatusNotFound, &body)
+}
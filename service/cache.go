@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultResponseTTL keeps successful responses long enough for a session
+// list and the seat map opened from it to share requests, and short enough
+// that seat availability stays current.
+const defaultResponseTTL = 10 * time.Second
+
+// responseCache deduplicates identical in-flight GETs and keeps successful
+// response bodies in memory for ttl.
+type responseCache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	entries  map[string]cachedResponse
+	inflight map[string]*inflightRequest
+}
+
+type cachedResponse struct {
+	body    []byte
+	expires time.Time
+}
+
+type inflightRequest struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:      ttl,
+		entries:  map[string]cachedResponse{},
+		inflight: map[string]*inflightRequest{},
+	}
+}
+
+// lookup returns a cached body that has not expired yet.
+func (r *responseCache) lookup(endpoint string, now time.Time) ([]byte, bool) {
+	entry, ok := r.entries[endpoint]
+	if !ok || !now.Before(entry.expires) {
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// store saves body and drops the entries that have expired meanwhile.
+func (r *responseCache) store(endpoint string, body []byte, now time.Time) {
+	if r.ttl <= 0 {
+		return
+	}
+	for key, entry := range r.entries {
+		if !now.Before(entry.expires) {
+			delete(r.entries, key)
+		}
+	}
+	r.entries[endpoint] = cachedResponse{body: body, expires: now.Add(r.ttl)}
+}
+
+type skipCacheKey struct{}
+
+// WithoutResponseCache returns a context whose requests bypass the in-memory
+// response cache, for callers polling for changes such as a seat watch.
+// Identical requests already in flight are still shared.
+func WithoutResponseCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheKey{}, true)
+}
+
+func skipResponseCache(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipCacheKey{}).(bool)
+	return skip
+}
+
+// get returns the body of a successful GET to endpoint. Fresh cached bodies
+// are returned as is, and callers asking for an endpoint that is already being
+// fetched wait for that request instead of sending their own.
+func (c *Client) get(ctx context.Context, endpoint string) ([]byte, error) {
+	r := c.responses
+	for {
+		r.mu.Lock()
+		if !skipResponseCache(ctx) {
+			if body, ok := r.lookup(endpoint, time.Now()); ok {
+				r.mu.Unlock()
+				c.emit(ctx, RequestEvent{Source: SourceIngresso, URL: endpoint, Bytes: len(body), Cache: CacheMemory})
+				return body, nil
+			}
+		}
+		if call, ok := r.inflight[endpoint]; ok {
+			r.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if isContextError(call.err) && ctx.Err() == nil {
+				// The caller that sent the request gave up; send it again.
+				continue
+			}
+			return call.body, call.err
+		}
+		call := &inflightRequest{done: make(chan struct{})}
+		r.inflight[endpoint] = call
+		r.mu.Unlock()
+
+		call.body, call.err = c.fetch(ctx, endpoint)
+
+		r.mu.Lock()
+		delete(r.inflight, endpoint)
+		if call.err == nil {
+			r.store(endpoint, call.body, time.Now())
+		}
+		r.mu.Unlock()
+		close(call.done)
+		return call.body, call.err
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
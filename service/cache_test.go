@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetJSON_CoalescesConcurrentRequests(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var out map[string]bool
+			errs <- client.getJSON(context.Background(), server.URL+"/seats", &out)
+		}()
+	}
+	for atomic.LoadInt32(&requests) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestGetJSON_CachesSuccessfulResponses(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), WithResponseCacheTTL(time.Minute))
+	var out map[string]bool
+	if err := client.getJSON(context.Background(), server.URL+"/seats", &out); err == nil {
+		t.Fatal("expected the first request to fail")
+	}
+	for range 2 {
+		if err := client.getJSON(context.Background(), server.URL+"/seats", &out); err != nil || !out["ok"] {
+			t.Fatalf("unexpected result %v, %v", out, err)
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("expected errors not to be cached and successes to be, got %d requests", requests)
+	}
+
+	if err := client.getJSON(WithoutResponseCache(context.Background()), server.URL+"/seats", &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 3 {
+		t.Fatalf("expected WithoutResponseCache to send a request, got %d requests", requests)
+	}
+}
+
+func TestGetJSON_ResponseCacheCanBeDisabled(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), WithResponseCacheTTL(-1))
+	var out map[string]any
+	for range 2 {
+		if err := client.getJSON(context.Background(), server.URL+"/cities", &out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+}
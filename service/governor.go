@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultConcurrency is how many requests a Client sends at once while
+	// the API answers normally.
+	defaultConcurrency = 6
+	// maxRetryAfter bounds how long a Retry-After header can pause requests.
+	maxRetryAfter = 30 * time.Second
+)
+
+// governor limits the requests a Client has in flight. The limit follows
+// AIMD: every answered request raises it by about one per window up to max,
+// and every 429 or 5xx halves it. A Retry-After header pauses every request
+// until it elapses.
+//
+// It covers only the Ingresso endpoints the Client calls. OMDb, TMDb, poster
+// and IP location requests go to other hosts with their own limits, are sent
+// one at a time per movie or lookup, and use their own http clients.
+type governor struct {
+	max int
+
+	mu          sync.Mutex
+	limit       float64
+	active      int
+	pausedUntil time.Time
+	wake        chan struct{}
+}
+
+func newGovernor(max int) *governor {
+	return &governor{max: max, limit: float64(max), wake: make(chan struct{})}
+}
+
+// acquire blocks until a request may be sent or ctx is done. Every successful
+// acquire must be paired with a call to release.
+func (g *governor) acquire(ctx context.Context) error {
+	for {
+		g.mu.Lock()
+		wait := time.Until(g.pausedUntil)
+		if wait <= 0 && g.active < int(g.limit) {
+			g.active++
+			g.mu.Unlock()
+			return nil
+		}
+		wake := g.wake
+		g.mu.Unlock()
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			expired = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return ctx.Err()
+		case <-wake:
+		case <-expired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// release frees the slot taken by acquire and adapts the limit to status, the
+// HTTP status of the response or 0 when the request failed without one. It
+// returns the new limit.
+func (g *governor) release(status int, retryAfter time.Duration) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.active--
+	switch {
+	case status == http.StatusTooManyRequests || status >= http.StatusInternalServerError:
+		g.limit = max(g.limit/2, 1)
+	case status > 0:
+		g.limit = min(g.limit+1/g.limit, float64(g.max))
+	}
+	if retryAfter > 0 {
+		if until := time.Now().Add(min(retryAfter, maxRetryAfter)); until.After(g.pausedUntil) {
+			g.pausedUntil = until
+		}
+	}
+	close(g.wake)
+	g.wake = make(chan struct{})
+	return int(g.limit)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
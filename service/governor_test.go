@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGovernor_AIMD(t *testing.T) {
+	g := newGovernor(8)
+	ctx := context.Background()
+
+	for _, want := range []int{4, 2, 1, 1} {
+		if err := g.acquire(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if got := g.release(http.StatusServiceUnavailable, 0); got != want {
+			t.Fatalf("expected limit %d after an overload, got %d", want, got)
+		}
+	}
+
+	// A 404 is a normal answer and counts toward recovery.
+	limit := 1
+	for i := 0; i < 40 && limit < 8; i++ {
+		_ = g.acquire(ctx)
+		limit = g.release(http.StatusNotFound, 0)
+	}
+	if limit != 8 {
+		t.Fatalf("expected the limit to recover to 8, got %d", limit)
+	}
+}
+
+func TestGovernor_BlocksAtLimit(t *testing.T) {
+	g := newGovernor(1)
+	if err := g.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := g.acquire(ctx); err == nil {
+		t.Fatal("expected the second acquire to wait for the first release")
+	}
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- g.acquire(context.Background()) }()
+	g.release(http.StatusOK, 0)
+	if err := <-acquired; err != nil {
+		t.Fatalf("expected the waiting acquire to proceed, got %v", err)
+	}
+}
+
+func TestGovernor_HonorsRetryAfter(t *testing.T) {
+	g := newGovernor(4)
+	_ = g.acquire(context.Background())
+	g.release(http.StatusTooManyRequests, 50*time.Millisecond)
+
+	start := time.Now()
+	if err := g.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected to wait for Retry-After, waited %s", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"3":                             3 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Thu, 15 Oct 2026 12:00:10 GMT": 10 * time.Second,
+	}
+	for value, want := range cases {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Fatalf("parseRetryAfter(%q): expected %s, got %s", value, want, got)
+		}
+	}
+}
+
+func TestRetryDelay_JitterStaysWithinBackoff(t *testing.T) {
+	client := NewClient(nil, WithRetries(0, 100*time.Millisecond, 400*time.Millisecond))
+	for attempt, backoff := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: 400 * time.Millisecond} {
+		for range 50 {
+			delay := client.retryDelay(attempt)
+			if delay < backoff/2 || delay > backoff {
+				t.Fatalf("attempt %d: delay %s outside [%s, %s]", attempt, delay, backoff/2, backoff)
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -44,13 +45,31 @@ var defaultLocationProviders = []locationProvider{
 
 var detectSystemLocationFn = detectCurrentLocationFromSystem
 
+// LocationHosts returns the hosts asked to geolocate the user's IP address.
+// Their answers tell where the user is, so recordings leave them out.
+func LocationHosts() []string {
+	hosts := make([]string, 0, len(defaultLocationProviders))
+	for _, provider := range defaultLocationProviders {
+		if endpoint, err := url.Parse(provider.endpoint); err == nil {
+			hosts = append(hosts, endpoint.Hostname())
+		}
+	}
+	return hosts
+}
+
 // DetectCurrentLocation resolves the current user location, prioritizing system APIs and falling back to IP geolocation.
 func DetectCurrentLocation(ctx context.Context, httpClient *http.Client) (UserLocation, error) {
 	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 8 * time.Second}
+		httpClient = newHTTPClient(8 * time.Second)
 	}
 
+	start := time.Now()
 	systemLocation, err := detectSystemLocationFn(ctx)
+	event := RequestEvent{Source: SourceLocation, URL: "system", Attempt: 1, Latency: time.Since(start)}
+	if err != nil {
+		event.Err = err.Error()
+	}
+	Emit(event)
 	if err == nil {
 		if strings.TrimSpace(systemLocation.Source) == "" {
 			systemLocation.Source = "system"
@@ -118,7 +137,21 @@ func detectCurrentLocationWithProviders(ctx context.Context, httpClient *http.Cl
 	return UserLocation{}, fmt.Errorf("all location providers failed (%s)", strings.Join(providerErrors, " | "))
 }
 
+// detectCurrentLocationFromProvider asks one provider and emits a RequestEvent
+// describing the attempt.
 func detectCurrentLocationFromProvider(ctx context.Context, httpClient *http.Client, provider locationProvider) (UserLocation, error) {
+	event := RequestEvent{Source: SourceLocation, URL: provider.endpoint, Attempt: 1}
+	start := time.Now()
+	location, err := requestProviderLocation(ctx, httpClient, provider, &event)
+	event.Latency = time.Since(start)
+	if err != nil {
+		event.Err = err.Error()
+	}
+	Emit(event)
+	return location, err
+}
+
+func requestProviderLocation(ctx context.Context, httpClient *http.Client, provider locationProvider, event *RequestEvent) (UserLocation, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.endpoint, nil)
 	if err != nil {
 		return UserLocation{}, fmt.Errorf("create location request: %w", err)
@@ -131,9 +164,11 @@ func detectCurrentLocationFromProvider(ctx context.Context, httpClient *http.Cli
 		return UserLocation{}, fmt.Errorf("location request failed: %w", err)
 	}
 	defer res.Body.Close()
+	event.Status = res.StatusCode
 
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
 		snippet, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+		event.Bytes = len(snippet)
 		msg := compactProviderErrorSnippet(string(snippet))
 		if msg == "" {
 			return UserLocation{}, errors.New(res.Status)
@@ -142,6 +177,7 @@ func detectCurrentLocationFromProvider(ctx context.Context, httpClient *http.Cli
 	}
 
 	body, err := io.ReadAll(io.LimitReader(res.Body, 64<<10))
+	event.Bytes = len(body)
 	if err != nil {
 		return UserLocation{}, fmt.Errorf("read location response: %w", err)
 	}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrMovieNotFound is returned when a metadata provider has no movie matching
+// a query.
+var ErrMovieNotFound = errors.New("movie not found")
+
+// MovieQuery describes the movie to look up.
+type MovieQuery struct {
+	Title         string
+	OriginalTitle string
+	// Year is the expected release year; 0 when unknown.
+	Year int
+	// Runtime is the duration in minutes announced by Ingresso; 0 when unknown.
+	Runtime int
+	// ImdbID pins the lookup to one IMDb title, once a provider identified it.
+	ImdbID string
+}
+
+// MovieMetadata is what a provider knows about a movie. Fields a provider
+// does not offer are left empty.
+type MovieMetadata struct {
+	// Provider names the provider that matched the movie.
+	Provider string
+	// ImdbID identifies the matched title, when the provider knows it.
+	ImdbID   string
+	Title    string
+	Year     string
+	Genre    string
+	Director string
+	Plot     string
+	Cast     []string
+	Trailer  string
+	// Poster is the URL of the poster image.
+	Poster     string
+	ImdbRating string
+	Metascore  string
+	Rotten     string
+	TMDbRating string
+}
+
+// MetadataProvider looks up ratings, synopses and credits for a movie.
+type MetadataProvider interface {
+	// Name identifies the provider in config files and caches.
+	Name() string
+	// Enabled reports whether the provider is configured, typically with an API key.
+	Enabled() bool
+	// MovieMetadata returns ErrMovieNotFound when the provider has no match.
+	MovieMetadata(ctx context.Context, query MovieQuery) (MovieMetadata, error)
+}
+
+// MetadataChain asks its providers in priority order. The first match sets
+// the title, synopsis and credits; later providers only fill the fields it
+// left empty, so TMDb's Portuguese synopsis can sit next to OMDb's IMDb rating.
+// Later providers are pinned to the IMDb id of the first match, and answers
+// about another movie are not merged.
+type MetadataChain struct {
+	providers []MetadataProvider
+}
+
+// NewMetadataChain builds a chain over providers, highest priority first.
+func NewMetadataChain(providers ...MetadataProvider) *MetadataChain {
+	return &MetadataChain{providers: providers}
+}
+
+// Name joins the names of the enabled providers, such as "tmdb+omdb".
+func (c *MetadataChain) Name() string {
+	var names []string
+	for _, provider := range c.enabled() {
+		names = append(names, provider.Name())
+	}
+	return strings.Join(names, "+")
+}
+
+// Enabled reports whether any provider is enabled.
+func (c *MetadataChain) Enabled() bool {
+	return len(c.enabled()) > 0
+}
+
+// MovieMetadata implements MetadataProvider. Errors from one provider do not
+// stop the others; the chain fails only when no provider matched.
+func (c *MetadataChain) MovieMetadata(ctx context.Context, query MovieQuery) (MovieMetadata, error) {
+	var result MovieMetadata
+	var firstErr error
+	found := false
+	for _, provider := range c.enabled() {
+		metadata, err := provider.MovieMetadata(ctx, query)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return MovieMetadata{}, err
+			}
+			if firstErr == nil && !errors.Is(err, ErrMovieNotFound) {
+				firstErr = err
+			}
+			continue
+		}
+		if !found {
+			result, found = metadata, true
+			query.ImdbID = metadata.ImdbID
+			continue
+		}
+		if sameMovie(result, metadata) {
+			fillMissing(&result, metadata)
+		}
+	}
+	if found {
+		return result, nil
+	}
+	if firstErr != nil {
+		return MovieMetadata{}, firstErr
+	}
+	return MovieMetadata{}, ErrMovieNotFound
+}
+
+func (c *MetadataChain) enabled() []MetadataProvider {
+	if c == nil {
+		return nil
+	}
+	var providers []MetadataProvider
+	for _, provider := range c.providers {
+		if provider != nil && provider.Enabled() {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// sameMovie reports whether two answers describe the same movie: by IMDb id
+// when both know it, else by release year when both know it.
+func sameMovie(a, b MovieMetadata) bool {
+	if a.ImdbID != "" && b.ImdbID != "" {
+		return a.ImdbID == b.ImdbID
+	}
+	if a.Year != "" && b.Year != "" {
+		return a.Year == b.Year
+	}
+	return true
+}
+
+// fillMissing copies into dst every field of src that dst leaves empty.
+func fillMissing(dst *MovieMetadata, src MovieMetadata) {
+	for _, field := range []struct {
+		dst *string
+		src string
+	}{
+		{&dst.Title, src.Title},
+		{&dst.Year, src.Year},
+		{&dst.Genre, src.Genre},
+		{&dst.Director, src.Director},
+		{&dst.Plot, src.Plot},
+		{&dst.Trailer, src.Trailer},
+		{&dst.Poster, src.Poster},
+		{&dst.ImdbRating, src.ImdbRating},
+		{&dst.Metascore, src.Metascore},
+		{&dst.Rotten, src.Rotten},
+		{&dst.TMDbRating, src.TMDbRating},
+	} {
+		if *field.dst == "" {
+			*field.dst = field.src
+		}
+	}
+	if len(dst.Cast) == 0 {
+		dst.Cast = src.Cast
+	}
+}
+
+// notAvailable clears OMDb's "N/A" placeholder.
+func notAvailable(value string) string {
+	if value == "N/A" {
+		return ""
+	}
+	return value
+}
+
+// getMetadataJSON sends req, emits a RequestEvent for it and decodes the body
+// into out. Metadata requests do not go through the Ingresso governor.
+func getMetadataJSON(httpClient *http.Client, source string, req *http.Request, out any) error {
+	event := RequestEvent{Source: source, URL: req.URL.String(), Attempt: 1}
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		event.Latency, event.Err = time.Since(start), err.Error()
+		Emit(event)
+		return fmt.Errorf("failed to reach %s api: %w", source, err)
+	}
+	event.Status = resp.StatusCode
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	event.Latency, event.Bytes = time.Since(start), len(body)
+	if err == nil && resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%s api returned status code %d", source, resp.StatusCode)
+	} else if err == nil {
+		if err = json.Unmarshal(body, out); err != nil {
+			err = fmt.Errorf("failed to decode %s response: %w", source, err)
+		}
+	}
+	if err != nil {
+		event.Err = err.Error()
+	}
+	Emit(event)
+	return err
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeProvider struct {
+	name     string
+	enabled  bool
+	metadata MovieMetadata
+	err      error
+	// queries records the queries the provider was asked, when set.
+	queries *[]MovieQuery
+}
+
+func (p fakeProvider) Name() string  { return p.name }
+func (p fakeProvider) Enabled() bool { return p.enabled }
+func (p fakeProvider) MovieMetadata(_ context.Context, query MovieQuery) (MovieMetadata, error) {
+	if p.queries != nil {
+		*p.queries = append(*p.queries, query)
+	}
+	return p.metadata, p.err
+}
+
+func TestMetadataChain_FillsGapsFromLaterProviders(t *testing.T) {
+	chain := NewMetadataChain(
+		fakeProvider{name: "tmdb", enabled: true, metadata: MovieMetadata{Provider: "tmdb", Plot: "Sinopse em português.", Genre: "Terror"}},
+		fakeProvider{name: "omdb", enabled: true, metadata: MovieMetadata{Provider: "omdb", Plot: "English plot.", ImdbRating: "7.3"}},
+		fakeProvider{name: "off", metadata: MovieMetadata{Director: "nobody"}},
+	)
+	if chain.Name() != "tmdb+omdb" {
+		t.Fatalf("Name() = %q, want tmdb+omdb", chain.Name())
+	}
+
+	metadata, err := chain.MovieMetadata(context.Background(), MovieQuery{Title: "Nosferatu"})
+	if err != nil {
+		t.Fatalf("MovieMetadata() error = %v", err)
+	}
+	if metadata.Provider != "tmdb" || metadata.Plot != "Sinopse em português." {
+		t.Fatalf("MovieMetadata() = %+v, want the first provider to win", metadata)
+	}
+	if metadata.ImdbRating != "7.3" || metadata.Director != "" {
+		t.Fatalf("MovieMetadata() = %+v, want gaps filled by enabled providers only", metadata)
+	}
+}
+
+func TestMetadataChain_PinsLaterProvidersToTheFirstMatch(t *testing.T) {
+	var queries []MovieQuery
+	chain := NewMetadataChain(
+		fakeProvider{name: "tmdb", enabled: true, metadata: MovieMetadata{Provider: "tmdb", ImdbID: "tt5040012", Plot: "Sinopse em português."}},
+		fakeProvider{name: "omdb", enabled: true, metadata: MovieMetadata{Provider: "omdb", ImdbID: "tt0013442", ImdbRating: "7.8", Director: "F. W. Murnau"}, queries: &queries},
+	)
+
+	metadata, err := chain.MovieMetadata(context.Background(), MovieQuery{Title: "Nosferatu"})
+	if err != nil {
+		t.Fatalf("MovieMetadata() error = %v", err)
+	}
+	if len(queries) != 1 || queries[0].ImdbID != "tt5040012" {
+		t.Fatalf("expected OMDb pinned to the TMDb match, got %+v", queries)
+	}
+	if metadata.ImdbRating != "" || metadata.Director != "" {
+		t.Fatalf("MovieMetadata() = %+v, want nothing merged from another movie", metadata)
+	}
+}
+
+func TestMetadataChain_FallsBackOnErrors(t *testing.T) {
+	failure := errors.New("boom")
+	chain := NewMetadataChain(
+		fakeProvider{name: "tmdb", enabled: true, err: failure},
+		fakeProvider{name: "omdb", enabled: true, err: ErrMovieNotFound},
+	)
+	if _, err := chain.MovieMetadata(context.Background(), MovieQuery{}); !errors.Is(err, failure) {
+		t.Fatalf("MovieMetadata() error = %v, want the provider failure", err)
+	}
+
+	chain = NewMetadataChain(fakeProvider{name: "omdb", enabled: true, err: ErrMovieNotFound})
+	if _, err := chain.MovieMetadata(context.Background(), MovieQuery{}); !errors.Is(err, ErrMovieNotFound) {
+		t.Fatalf("MovieMetadata() error = %v, want ErrMovieNotFound", err)
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recentEventLimit is how many events RecentEvents keeps.
+const recentEventLimit = 200
+
+// Event sources.
+const (
+	SourceIngresso = "ingresso"
+	SourceOMDb     = "omdb"
+	SourceTMDb     = "tmdb"
+	SourcePoster   = "poster"
+	SourceLocation = "location"
+)
+
+// Cache layers that can answer a lookup instead of the network.
+const (
+	CacheMemory     = "memory"
+	CacheStore      = "store"
+	CacheStoreStale = "store-stale"
+)
+
+// RequestEvent describes one HTTP attempt or one lookup answered by a cache,
+// for the debug log and the TUI request inspector.
+type RequestEvent struct {
+	Time    time.Time     `json:"time"`
+	Source  string        `json:"source"`
+	URL     string        `json:"url"`
+	Status  int           `json:"status,omitempty"`
+	Attempt int           `json:"attempt,omitempty"`
+	Latency time.Duration `json:"latency,omitempty"`
+	Bytes   int           `json:"bytes,omitempty"`
+	Cache   string        `json:"cache,omitempty"`
+	Err     string        `json:"error,omitempty"`
+}
+
+type observer struct {
+	id int
+	fn func(RequestEvent)
+}
+
+var (
+	observeMu      sync.Mutex
+	observers      []observer
+	nextObserverID int
+	recentEvents   []RequestEvent
+)
+
+// AddObserver calls fn for every event emitted from now on, until the
+// returned function removes it. fn runs on the goroutine that made the
+// request and must not block.
+func AddObserver(fn func(RequestEvent)) (remove func()) {
+	observeMu.Lock()
+	defer observeMu.Unlock()
+	nextObserverID++
+	id := nextObserverID
+	observers = append(observers, observer{id: id, fn: fn})
+	return func() {
+		observeMu.Lock()
+		defer observeMu.Unlock()
+		observers = slices.DeleteFunc(observers, func(o observer) bool { return o.id == id })
+	}
+}
+
+// RecentEvents returns the last events emitted, oldest first.
+func RecentEvents() []RequestEvent {
+	observeMu.Lock()
+	defer observeMu.Unlock()
+	return append([]RequestEvent(nil), recentEvents...)
+}
+
+// Emit records event and hands it to the observers. The time is filled in
+// when missing and credentials are removed from the URL.
+func Emit(event RequestEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	event.URL = redactURL(event.URL)
+
+	observeMu.Lock()
+	recentEvents = append(recentEvents, event)
+	if len(recentEvents) > recentEventLimit {
+		recentEvents = append(recentEvents[:0], recentEvents[len(recentEvents)-recentEventLimit:]...)
+	}
+	notify := append([]observer{}, observers...)
+	observeMu.Unlock()
+
+	for _, o := range notify {
+		o.fn(event)
+	}
+}
+
+// redactURL drops the OMDb apikey and similar credentials from raw.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.RawQuery == "" {
+		return raw
+	}
+	query := u.Query()
+	for _, name := range []string{"apikey", "api_key", "key", "token"} {
+		if query.Has(name) {
+			query.Set(name, "REDACTED")
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// String formats the event as one line of the debug log.
+func (e RequestEvent) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %-8s", e.Time.Format("2006-01-02 15:04:05.000"), e.Source)
+	if e.Cache != "" {
+		fmt.Fprintf(&b, " cache=%s", e.Cache)
+	}
+	if e.Attempt > 0 {
+		fmt.Fprintf(&b, " attempt=%d", e.Attempt)
+	}
+	if e.Status > 0 {
+		fmt.Fprintf(&b, " status=%d", e.Status)
+	}
+	if e.Latency > 0 {
+		fmt.Fprintf(&b, " latency=%s", e.Latency.Round(time.Millisecond))
+	}
+	if e.Bytes > 0 {
+		fmt.Fprintf(&b, " bytes=%d", e.Bytes)
+	}
+	b.WriteString(" " + e.URL)
+	if e.Err != "" {
+		fmt.Fprintf(&b, " error=%q", e.Err)
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEmit_RecordsRequestsAndCacheHits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var events []RequestEvent
+	remove := AddObserver(func(event RequestEvent) {
+		if !strings.HasPrefix(event.URL, server.URL) {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	})
+	defer remove()
+
+	client := NewClient(server.Client())
+	var out map[string]bool
+	for range 2 {
+		if err := client.getJSON(context.Background(), server.URL+"/cities?apikey=secret", &out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 {
+		t.Fatalf("expected a request and a cache hit, got %+v", events)
+	}
+	request, hit := events[0], events[1]
+	if request.Source != SourceIngresso || request.Status != http.StatusOK || request.Attempt != 1 || request.Bytes != len(`{"ok": true}`) || request.Cache != "" {
+		t.Fatalf("unexpected request event: %+v", request)
+	}
+	if hit.Cache != CacheMemory {
+		t.Fatalf("expected a memory cache hit, got %+v", hit)
+	}
+	if strings.Contains(request.URL, "secret") || strings.Contains(request.String(), "secret") {
+		t.Fatalf("expected the apikey to be redacted, got %s", request.URL)
+	}
+	if !strings.Contains(request.String(), "status=200") {
+		t.Fatalf("unexpected log line: %s", request.String())
+	}
+
+	recent := RecentEvents()
+	if len(recent) == 0 || recent[len(recent)-1].Cache != CacheMemory {
+		t.Fatalf("expected the cache hit to be the latest recent event, got %+v", recent)
+	}
+}
+
+func TestAddObserver_Remove(t *testing.T) {
+	calls := 0
+	remove := AddObserver(func(event RequestEvent) {
+		if event.URL == "https://example.com/observed" {
+			calls++
+		}
+	})
+	Emit(RequestEvent{Source: SourceIngresso, URL: "https://example.com/observed"})
+	remove()
+	Emit(RequestEvent{Source: SourceIngresso, URL: "https://example.com/observed"})
+	if calls != 1 {
+		t.Fatalf("expected one call before removal, got %d", calls)
+	}
+}
@@ -1,11 +1,14 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -29,6 +32,7 @@ type OMDbResponse struct {
 	Runtime    string       `json:"Runtime"`
 	Genre      string       `json:"Genre"`
 	Director   string       `json:"Director"`
+	Actors     string       `json:"Actors"`
 	Plot       string       `json:"Plot"`
 	Language   string       `json:"Language"`
 	Poster     string       `json:"Poster"`
@@ -40,70 +44,281 @@ type OMDbResponse struct {
 	Error      string       `json:"Error"`
 }
 
-// FetchMovieData queries the OMDb API for movie details by title.
-func FetchMovieData(title, originalTitle string) (*OMDbResponse, error) {
-	apiKey := os.Getenv("OMDB_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("OMDB_API_KEY environment variable is not set")
+// maxRuntimeDiff is how far, in minutes, an exact title match may be from the
+// runtime announced by Ingresso before the search fallback is tried.
+const maxRuntimeDiff = 10
+
+// maxSearchCandidates bounds how many search results get a details lookup.
+const maxSearchCandidates = 3
+
+// OMDbOption configures an OMDbClient.
+type OMDbOption func(*OMDbClient)
+
+// WithOMDbBaseURL points the client at baseURL instead of omdbapi.com.
+func WithOMDbBaseURL(baseURL string) OMDbOption {
+	return func(c *OMDbClient) {
+		if baseURL = strings.TrimSpace(baseURL); baseURL != "" {
+			c.baseURL = baseURL
+		}
+	}
+}
+
+// OMDbClient looks up movie ratings and details on OMDb.
+type OMDbClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+}
+
+// NewOMDbClient creates a client using apiKey. If httpClient is nil, a default
+// client is used.
+func NewOMDbClient(apiKey string, httpClient *http.Client, opts ...OMDbOption) *OMDbClient {
+	if httpClient == nil {
+		httpClient = newHTTPClient(omdbTimeout)
 	}
+	c := &OMDbClient{httpClient: httpClient, baseURL: omdbBaseURL, apiKey: strings.TrimSpace(apiKey)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// NewOMDbClientFromEnv reads the key from OMDB_API_KEY and an optional base URL
+// from OMDB_API_URL.
+func NewOMDbClientFromEnv() *OMDbClient {
+	return NewOMDbClient(os.Getenv("OMDB_API_KEY"), nil, WithOMDbBaseURL(os.Getenv("OMDB_API_URL")))
+}
+
+// Enabled reports whether the client has an API key.
+func (c *OMDbClient) Enabled() bool {
+	return c != nil && c.apiKey != ""
+}
+
+// Name implements MetadataProvider.
+func (c *OMDbClient) Name() string {
+	return "omdb"
+}
 
-	titlesToTry := []string{}
+// MovieMetadata implements MetadataProvider with the IMDb, Metacritic and
+// Rotten Tomatoes ratings that OMDb aggregates.
+func (c *OMDbClient) MovieMetadata(ctx context.Context, query MovieQuery) (MovieMetadata, error) {
+	data, err := c.Lookup(ctx, query)
+	if err != nil {
+		return MovieMetadata{}, err
+	}
+	metadata := MovieMetadata{
+		Provider:   c.Name(),
+		ImdbID:     notAvailable(data.ImdbID),
+		Title:      notAvailable(data.Title),
+		Year:       notAvailable(data.Year),
+		Genre:      notAvailable(data.Genre),
+		Director:   notAvailable(data.Director),
+		Plot:       notAvailable(data.Plot),
+		Poster:     notAvailable(data.Poster),
+		ImdbRating: notAvailable(data.ImdbRating),
+		Metascore:  notAvailable(data.Metascore),
+	}
+	if actors := notAvailable(data.Actors); actors != "" {
+		metadata.Cast = strings.Split(actors, ", ")
+	}
+	for _, r := range data.Ratings {
+		if r.Source == "Rotten Tomatoes" {
+			metadata.Rotten = r.Value
+			break
+		}
+	}
+	return metadata, nil
+}
 
-	cleanOrig := cleanTitleForSearch(originalTitle)
-	cleanPt := cleanTitleForSearch(title)
+// Lookup finds the movie described by query. A query pinned to an IMDb id
+// loads that title only. Otherwise each title is first tried as an exact
+// match for the expected year; when that misses or its runtime is too far
+// from query.Runtime, an OMDb search is scored by year and runtime.
+func (c *OMDbClient) Lookup(ctx context.Context, query MovieQuery) (*OMDbResponse, error) {
+	if !c.Enabled() {
+		return nil, fmt.Errorf("OMDB_API_KEY environment variable is not set")
+	}
+	if query.ImdbID != "" {
+		var pinned OMDbResponse
+		if err := c.get(ctx, url.Values{"i": {query.ImdbID}}, &pinned); err != nil {
+			return nil, err
+		}
+		if pinned.Response != "True" {
+			return nil, ErrMovieNotFound
+		}
+		return &pinned, nil
+	}
 
+	var titles []string
+	cleanOrig := cleanTitleForSearch(query.OriginalTitle)
+	cleanPt := cleanTitleForSearch(query.Title)
 	if cleanOrig != "" {
-		titlesToTry = append(titlesToTry, cleanOrig)
+		titles = append(titles, cleanOrig)
 	}
 	if cleanPt != "" && cleanPt != cleanOrig {
-		titlesToTry = append(titlesToTry, cleanPt)
+		titles = append(titles, cleanPt)
 	}
-	if len(titlesToTry) == 0 {
+	if len(titles) == 0 {
 		return nil, fmt.Errorf("no valid title provided for search")
 	}
 
 	var lastErr error
-	for _, t := range titlesToTry {
-		reqURL, err := url.Parse(omdbBaseURL)
+	for _, title := range titles {
+		params := url.Values{"t": {title}, "type": {"movie"}}
+		if query.Year > 0 {
+			params.Set("y", strconv.Itoa(query.Year))
+		}
+		var exact OMDbResponse
+		err := c.get(ctx, params, &exact)
+		if err == nil && exact.Response == "True" && runtimeDiff(query.Runtime, exact.Runtime) <= maxRuntimeDiff {
+			return &exact, nil
+		}
 		if err != nil {
-			return nil, err
+			lastErr = err
 		}
 
-		q := reqURL.Query()
-		q.Set("apikey", apiKey)
-		q.Set("t", t)
-		reqURL.RawQuery = q.Encode()
+		best, err := c.search(ctx, title, query)
+		if err == nil {
+			return best, nil
+		}
+		if !errors.Is(err, ErrMovieNotFound) {
+			lastErr = err
+		}
+	}
+	if lastErr != nil && !errors.Is(lastErr, ErrMovieNotFound) {
+		return nil, lastErr
+	}
+	return nil, ErrMovieNotFound
+}
 
-		client := &http.Client{Timeout: omdbTimeout}
-		resp, err := client.Get(reqURL.String())
-		if err != nil {
-			lastErr = fmt.Errorf("failed to reach omdb api: %w", err)
+type omdbSearchResponse struct {
+	Search []struct {
+		Title  string `json:"Title"`
+		Year   string `json:"Year"`
+		ImdbID string `json:"imdbID"`
+	} `json:"Search"`
+	Response string `json:"Response"`
+	Error    string `json:"Error"`
+}
+
+// search runs an s= search for title and returns the candidate closest to
+// query, loading the details of the best ranked results to compare runtimes.
+func (c *OMDbClient) search(ctx context.Context, title string, query MovieQuery) (*OMDbResponse, error) {
+	var results omdbSearchResponse
+	if err := c.get(ctx, url.Values{"s": {title}, "type": {"movie"}}, &results); err != nil {
+		return nil, err
+	}
+	if results.Response != "True" || len(results.Search) == 0 {
+		return nil, ErrMovieNotFound
+	}
+
+	candidates := results.Search
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return yearDiff(query.Year, candidates[i].Year) < yearDiff(query.Year, candidates[j].Year)
+	})
+	if len(candidates) > maxSearchCandidates {
+		candidates = candidates[:maxSearchCandidates]
+	}
+
+	var best *OMDbResponse
+	bestScore := 0
+	var lastErr error
+	for _, candidate := range candidates {
+		var details OMDbResponse
+		if err := c.get(ctx, url.Values{"i": {candidate.ImdbID}}, &details); err != nil {
+			lastErr = err
 			continue
 		}
-
-		if resp.StatusCode != http.StatusOK {
-			resp.Body.Close()
-			lastErr = fmt.Errorf("omdb api returned status code %d", resp.StatusCode)
+		if details.Response != "True" {
 			continue
 		}
+		score := matchScore(query, details.Year, details.Runtime)
+		if best == nil || score < bestScore {
+			best, bestScore = &details, score
+		}
+	}
+	if best == nil {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		return nil, ErrMovieNotFound
+	}
+	return best, nil
+}
 
-		var data OMDbResponse
-		err = json.NewDecoder(resp.Body).Decode(&data)
-		resp.Body.Close()
+// get sends one OMDb request and decodes its body into out.
+func (c *OMDbClient) get(ctx context.Context, params url.Values, out any) error {
+	reqURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+	q := reqURL.Query()
+	for key, values := range params {
+		q[key] = values
+	}
+	q.Set("apikey", c.apiKey)
+	reqURL.RawQuery = q.Encode()
 
-		if err != nil {
-			lastErr = fmt.Errorf("failed to decode omdb response: %w", err)
-			continue
-		}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	return getMetadataJSON(c.httpClient, SourceOMDb, req, out)
+}
 
-		if data.Response == "True" {
-			return &data, nil
-		} else {
-			lastErr = fmt.Errorf("omdb api error: %s", data.Error)
-		}
+// matchScore ranks a candidate against the query, lower is better: minutes of
+// runtime difference plus ten per year away from the expected year.
+func matchScore(query MovieQuery, year, runtime string) int {
+	return 10*yearDiff(query.Year, year) + runtimeDiff(query.Runtime, runtime)
+}
+
+// runtimeDiff compares minutes with a runtime such as "104 min" or "104". It
+// is zero when either side is unknown.
+func runtimeDiff(minutes int, runtime string) int {
+	if minutes <= 0 {
+		return 0
+	}
+	value, ok := leadingNumber(runtime)
+	if !ok || value <= 0 {
+		return 0
+	}
+	return abs(minutes - value)
+}
+
+// yearDiff compares year with a year such as "2024", "2019–2022" or a
+// "2024-05-01" release date.
+// A movie released the year before the hint counts as a match, since
+// Brazilian releases often come later.
+func yearDiff(year int, omdbYear string) int {
+	if year <= 0 {
+		return 0
+	}
+	value, ok := leadingNumber(omdbYear)
+	if !ok {
+		return 1
 	}
+	if value == year || value == year-1 {
+		return 0
+	}
+	return abs(year - value)
+}
 
-	return nil, lastErr
+func leadingNumber(value string) (int, bool) {
+	end := 0
+	for end < len(value) && value[end] >= '0' && value[end] <= '9' {
+		end++
+	}
+	number, err := strconv.Atoi(value[:end])
+	return number, err == nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
 }
 
 // cleanTitleForSearch removes common artifacts from localized movie titles
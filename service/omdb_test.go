@@ -1,6 +1,10 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -86,3 +90,91 @@ func TestCleanTitleForSearch(t *testing.T) {
 		})
 	}
 }
+
+// fakeOMDb serves a 2024 remake for exact title lookups and both versions in
+// searches, like OMDb does for "Nosferatu".
+func fakeOMDb(t *testing.T) *httptest.Server {
+	t.Helper()
+	movies := map[string]string{
+		"tt0013442": `{"Title":"Nosferatu","Year":"1922","Runtime":"94 min","imdbID":"tt0013442","imdbRating":"7.8","Response":"True"}`,
+		"tt5040012": `{"Title":"Nosferatu","Year":"2024","Runtime":"132 min","imdbID":"tt5040012","imdbRating":"7.2","Response":"True"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("apikey") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case q.Get("i") != "":
+			_, _ = w.Write([]byte(movies[q.Get("i")]))
+		case q.Get("t") == "Nosferatu":
+			_, _ = w.Write([]byte(movies["tt5040012"]))
+		case q.Get("s") == "Nosferatu":
+			_, _ = w.Write([]byte(`{"Search":[{"Title":"Nosferatu","Year":"2024","imdbID":"tt5040012"},{"Title":"Nosferatu","Year":"1922","imdbID":"tt0013442"}],"Response":"True"}`))
+		default:
+			_, _ = w.Write([]byte(`{"Response":"False","Error":"Movie not found!"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOMDbLookup_AcceptsExactMatch(t *testing.T) {
+	server := fakeOMDb(t)
+	client := NewOMDbClient("secret", server.Client(), WithOMDbBaseURL(server.URL))
+
+	movie, err := client.Lookup(context.Background(), MovieQuery{Title: "Nosferatu - Legendado", Year: 2025, Runtime: 133})
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if movie.ImdbID != "tt5040012" {
+		t.Fatalf("Lookup() = %s, want the 2024 remake", movie.ImdbID)
+	}
+}
+
+func TestOMDbLookup_SearchesWhenRuntimeDiffers(t *testing.T) {
+	server := fakeOMDb(t)
+	client := NewOMDbClient("secret", server.Client(), WithOMDbBaseURL(server.URL))
+
+	movie, err := client.Lookup(context.Background(), MovieQuery{Title: "Nosferatu", Year: 1922, Runtime: 94})
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if movie.ImdbID != "tt0013442" {
+		t.Fatalf("Lookup() = %s, want the 1922 original", movie.ImdbID)
+	}
+}
+
+func TestOMDbLookup_PinnedToImdbID(t *testing.T) {
+	server := fakeOMDb(t)
+	client := NewOMDbClient("secret", server.Client(), WithOMDbBaseURL(server.URL))
+
+	metadata, err := client.MovieMetadata(context.Background(), MovieQuery{Title: "Nosferatu", Year: 2025, ImdbID: "tt0013442"})
+	if err != nil {
+		t.Fatalf("MovieMetadata() error = %v", err)
+	}
+	if metadata.ImdbID != "tt0013442" || metadata.Year != "1922" {
+		t.Fatalf("MovieMetadata() = %+v, want the pinned 1922 original", metadata)
+	}
+}
+
+func TestOMDbLookup_NotFound(t *testing.T) {
+	server := fakeOMDb(t)
+	client := NewOMDbClient("secret", server.Client(), WithOMDbBaseURL(server.URL))
+
+	_, err := client.Lookup(context.Background(), MovieQuery{Title: "Filme Inexistente"})
+	if !errors.Is(err, ErrMovieNotFound) {
+		t.Fatalf("Lookup() error = %v, want ErrMovieNotFound", err)
+	}
+}
+
+func TestOMDbLookup_RequiresKey(t *testing.T) {
+	client := NewOMDbClient("", nil)
+	if client.Enabled() {
+		t.Fatal("Enabled() = true without a key")
+	}
+	if _, err := client.Lookup(context.Background(), MovieQuery{Title: "Nosferatu"}); err == nil {
+		t.Fatal("Lookup() without a key should fail")
+	}
+}
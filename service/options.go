@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Option configures a Client. Zero values leave the default in place.
+type Option func(*Client)
+
+// WithBaseURL points the content API (cities, theaters, sessions) at baseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/"); baseURL != "" {
+			c.baseURL = baseURL
+		}
+	}
+}
+
+// WithCheckoutURL points the checkout API (session details, seat maps) at checkoutURL.
+func WithCheckoutURL(checkoutURL string) Option {
+	return func(c *Client) {
+		if checkoutURL = strings.TrimRight(strings.TrimSpace(checkoutURL), "/"); checkoutURL != "" {
+			c.checkoutURL = checkoutURL
+		}
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		if userAgent = strings.TrimSpace(userAgent); userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// WithRetries sets how many attempts a request gets and the bounds of the
+// exponential backoff between them.
+func WithRetries(maxAttempts int, base, cap time.Duration) Option {
+	return func(c *Client) {
+		if maxAttempts > 0 {
+			c.maxAttempts = maxAttempts
+		}
+		if base > 0 {
+			c.retryBase = base
+		}
+		if cap > 0 {
+			c.retryCap = cap
+		}
+	}
+}
+
+// WithTimeout limits each HTTP attempt. The http.Client passed to NewClient is
+// copied, never modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		if timeout <= 0 {
+			return
+		}
+		httpClient := *c.httpClient
+		httpClient.Timeout = timeout
+		c.httpClient = &httpClient
+	}
+}
+
+// WithConcurrency sets how many Ingresso requests the client sends at once.
+// The limit drops when the API answers 429 or 5xx and recovers as requests
+// succeed.
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.governor = newGovernor(n)
+		}
+	}
+}
+
+// WithResponseCacheTTL sets how long successful responses are reused. A
+// negative ttl disables the cache; identical in-flight requests are still shared.
+func WithResponseCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		switch {
+		case ttl > 0:
+			c.responses.ttl = ttl
+		case ttl < 0:
+			c.responses.ttl = 0
+		}
+	}
+}
+
+// WithLogger logs the events the client hands to the observers, every request
+// attempt and cache hit, at debug level and failures at warn level.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// EnvOptions reads the client overrides from the environment:
+// INGRESSO_API_URL, INGRESSO_CHECKOUT_API_URL, INGRESSO_USER_AGENT,
+// INGRESSO_MAX_ATTEMPTS and INGRESSO_TIMEOUT.
+func EnvOptions() ([]Option, error) {
+	opts := []Option{
+		WithBaseURL(os.Getenv("INGRESSO_API_URL")),
+		WithCheckoutURL(os.Getenv("INGRESSO_CHECKOUT_API_URL")),
+		WithUserAgent(os.Getenv("INGRESSO_USER_AGENT")),
+	}
+	if value := strings.TrimSpace(os.Getenv("INGRESSO_MAX_ATTEMPTS")); value != "" {
+		attempts, err := strconv.Atoi(value)
+		if err != nil || attempts < 1 {
+			return nil, fmt.Errorf("invalid INGRESSO_MAX_ATTEMPTS %q", value)
+		}
+		opts = append(opts, WithRetries(attempts, 0, 0))
+	}
+	if value := strings.TrimSpace(os.Getenv("INGRESSO_TIMEOUT")); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid INGRESSO_TIMEOUT %q", value)
+		}
+		opts = append(opts, WithTimeout(timeout))
+	}
+	return opts, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClient_AppliesOptions(t *testing.T) {
+	base := &http.Client{Timeout: time.Minute}
+	client := NewClient(base,
+		WithBaseURL("http://localhost:9000/v0/"),
+		WithCheckoutURL("http://localhost:9000/v1"),
+		WithUserAgent("demo"),
+		WithRetries(5, time.Millisecond, 0),
+		WithTimeout(time.Second),
+		WithBaseURL(""),
+	)
+
+	if client.baseURL != "http://localhost:9000/v0" || client.checkoutURL != "http://localhost:9000/v1" {
+		t.Fatalf("unexpected urls: %q %q", client.baseURL, client.checkoutURL)
+	}
+	if client.userAgent != "demo" || client.maxAttempts != 5 || client.retryBase != time.Millisecond {
+		t.Fatalf("unexpected settings: %+v", client)
+	}
+	if client.retryCap != defaultRetryCap {
+		t.Fatalf("expected zero cap to keep the default, got %v", client.retryCap)
+	}
+	if client.httpClient.Timeout != time.Second || base.Timeout != time.Minute {
+		t.Fatalf("expected timeout on a copy of the http client, got %v and %v", client.httpClient.Timeout, base.Timeout)
+	}
+}
+
+func TestEnvOptions(t *testing.T) {
+	t.Setenv("INGRESSO_API_URL", "http://stand-in/v0")
+	t.Setenv("INGRESSO_MAX_ATTEMPTS", "2")
+	t.Setenv("INGRESSO_TIMEOUT", "3s")
+
+	opts, err := EnvOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewClient(nil, opts...)
+	if client.baseURL != "http://stand-in/v0" || client.checkoutURL != checkoutBaseURL {
+		t.Fatalf("unexpected urls: %q %q", client.baseURL, client.checkoutURL)
+	}
+	if client.maxAttempts != 2 || client.httpClient.Timeout != 3*time.Second {
+		t.Fatalf("unexpected settings: attempts=%d timeout=%v", client.maxAttempts, client.httpClient.Timeout)
+	}
+
+	t.Setenv("INGRESSO_TIMEOUT", "soon")
+	if _, err := EnvOptions(); err == nil || !strings.Contains(err.Error(), "INGRESSO_TIMEOUT") {
+		t.Fatalf("expected an invalid timeout error, got %v", err)
+	}
+}
+
+func TestWithLogger_LogsAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(server.Client(), WithBaseURL(server.URL), WithRetries(2, time.Millisecond, time.Millisecond), WithLogger(logger))
+
+	if _, err := client.GetCities(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := strings.Count(logs.String(), "msg=request "); got != 2 {
+		t.Fatalf("expected 2 logged attempts, got %d:\n%s", got, logs.String())
+	}
+	if !strings.Contains(logs.String(), "status=503") {
+		t.Fatalf("expected the status to be logged:\n%s", logs.String())
+	}
+}
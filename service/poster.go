@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	posterTimeout = 10 * time.Second
+	// maxPosterBytes guards against a poster URL that serves something huge.
+	maxPosterBytes = 5 << 20
+)
+
+// FetchPoster downloads the poster image at posterURL. If httpClient is nil, a
+// default client is used.
+func FetchPoster(ctx context.Context, httpClient *http.Client, posterURL string) ([]byte, error) {
+	if httpClient == nil {
+		httpClient = newHTTPClient(posterTimeout)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, posterURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	event := RequestEvent{Source: SourcePoster, URL: posterURL, Attempt: 1}
+	start := time.Now()
+	data, err := readPoster(httpClient, req, &event)
+	event.Latency = time.Since(start)
+	if err != nil {
+		event.Err = err.Error()
+	}
+	Emit(event)
+	return data, err
+}
+
+func readPoster(httpClient *http.Client, req *http.Request, event *RequestEvent) ([]byte, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download poster: %w", err)
+	}
+	defer resp.Body.Close()
+	event.Status = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("poster download returned status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPosterBytes+1))
+	event.Bytes = len(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download poster: %w", err)
+	}
+	if len(data) > maxPosterBytes {
+		return nil, fmt.Errorf("poster is larger than %d bytes", maxPosterBytes)
+	}
+	return data, nil
+}
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"strings"
@@ -17,10 +20,12 @@ import (
 const (
 	apiBaseURL         = "https://api-content.ingresso.com/v0"
 	checkoutBaseURL    = "https://api.ingresso.com/v1"
+	checkoutWebURL     = "https://checkout.ingresso.com/assentos?sessionId=%s&partnership=home"
 	defaultUserAgent   = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5.2 Safari/605.1.15"
 	defaultMaxAttempts = 3
 	defaultRetryBase   = 200 * time.Millisecond
 	defaultRetryCap    = 1200 * time.Millisecond
+	defaultTimeout     = 12 * time.Second
 )
 
 // Client wraps HTTP access to the Ingresso content API.
@@ -32,6 +37,9 @@ type Client struct {
 	maxAttempts int
 	retryBase   time.Duration
 	retryCap    time.Duration
+	logger      *slog.Logger
+	responses   *responseCache
+	governor    *governor
 }
 
 // APIError is returned when the Ingresso API responds with a non-2xx status.
@@ -59,11 +67,14 @@ func IsNotFound(err error) bool {
 }
 
 // NewClient creates a new API client. If httpClient is nil, a default client is used.
-func NewClient(httpClient *http.Client) *Client {
+// Options are applied in order, so later ones win. Identical concurrent GETs are
+// sent once and successful responses are reused for a few seconds; see
+// WithResponseCacheTTL.
+func NewClient(httpClient *http.Client, opts ...Option) *Client {
 	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 12 * time.Second}
+		httpClient = newHTTPClient(defaultTimeout)
 	}
-	return &Client{
+	c := &Client{
 		httpClient:  httpClient,
 		baseURL:     apiBaseURL,
 		checkoutURL: checkoutBaseURL,
@@ -71,7 +82,20 @@ func NewClient(httpClient *http.Client) *Client {
 		maxAttempts: defaultMaxAttempts,
 		retryBase:   defaultRetryBase,
 		retryCap:    defaultRetryCap,
+		responses:   newResponseCache(defaultResponseTTL),
+		governor:    newGovernor(defaultConcurrency),
 	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// CheckoutURL returns the web checkout page for a session.
+func CheckoutURL(sessionID string) string {
+	return fmt.Sprintf(checkoutWebURL, url.QueryEscape(sessionID))
 }
 
 // GetCityInfoByName fetches city info by its name.
@@ -140,6 +164,19 @@ func (c *Client) GetSeatMap(ctx context.Context, sessionID string, sectionID str
 	return seats, nil
 }
 
+// GetSectionTickets fetches the ticket categories and prices of a session section.
+func (c *Client) GetSectionTickets(ctx context.Context, sessionID string, sectionID string) ([]model.Ticket, error) {
+	if sessionID == "" || sectionID == "" {
+		return nil, errors.New("session id and section id are required")
+	}
+	endpoint := fmt.Sprintf("%s/sessions/%s/sections/%s/tickets", c.checkoutURL, sessionID, sectionID)
+	var tickets []model.Ticket
+	if err := c.getJSON(ctx, endpoint, &tickets); err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
 // GetTheatersByCity fetches theaters for a given city.
 func (c *Client) GetTheatersByCity(ctx context.Context, cityID string) ([]model.Theater, error) {
 	if cityID == "" {
@@ -172,31 +209,106 @@ func (c *Client) GetSessionsByCityAndTheater(ctx context.Context, cityID string,
 	return days, nil
 }
 
+// GetEventsByCity lists the movies showing in a city.
+func (c *Client) GetEventsByCity(ctx context.Context, cityID string) ([]model.Event, error) {
+	if cityID == "" {
+		return nil, errors.New("city id is required")
+	}
+	endpoint := fmt.Sprintf("%s/events/city/%s", c.baseURL, cityID)
+
+	var events []model.Event
+	if err := c.getJSON(ctx, endpoint, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetEvent fetches Ingresso's details of a movie: synopsis, credits, trailers and images.
+func (c *Client) GetEvent(ctx context.Context, eventID string) (model.Event, error) {
+	if eventID == "" {
+		return model.Event{}, errors.New("event id is required")
+	}
+	endpoint := fmt.Sprintf("%s/events/%s", c.baseURL, url.PathEscape(eventID))
+
+	var event model.Event
+	if err := c.getJSON(ctx, endpoint, &event); err != nil {
+		return model.Event{}, err
+	}
+	return event, nil
+}
+
+// GetSessionsByCityAndEvent fetches the sessions of one movie in every theater of a city.
+func (c *Client) GetSessionsByCityAndEvent(ctx context.Context, cityID string, eventID string, date *time.Time) ([]model.EventSessionDay, error) {
+	if cityID == "" || eventID == "" {
+		return nil, errors.New("city id and event id are required")
+	}
+
+	endpoint := fmt.Sprintf("%s/sessions/city/%s/event/%s", c.baseURL, cityID, eventID)
+	if date != nil {
+		endpoint = endpoint + "?date=" + date.Format(time.DateOnly)
+	}
+
+	var days []model.EventSessionDay
+	if err := c.getJSON(ctx, endpoint, &days); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
 func (c *Client) getJSON(ctx context.Context, endpoint string, out any) error {
+	body, err := c.get(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decode response from %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// fetch sends a GET to endpoint, retrying network errors and transient
+// statuses, and returns the body of the successful response. Every attempt
+// waits for a slot from the client's governor.
+func (c *Client) fetch(ctx context.Context, endpoint string) ([]byte, error) {
 	maxAttempts := max(c.maxAttempts, 1)
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 		if err != nil {
-			return fmt.Errorf("create request: %w", err)
+			return nil, fmt.Errorf("create request: %w", err)
 		}
 		req.Header.Set("User-Agent", c.userAgent)
 		req.Header.Set("Accept", "application/json")
 
+		if err := c.governor.acquire(ctx); err != nil {
+			return nil, err
+		}
+		event := RequestEvent{Source: SourceIngresso, URL: endpoint, Attempt: attempt}
+		start := time.Now()
 		res, err := c.httpClient.Do(req)
 		if err != nil {
+			c.governor.release(0, 0)
+			event.Latency, event.Err = time.Since(start), err.Error()
+			c.emit(ctx, event)
 			if c.shouldRetryNetworkError(err) && attempt < maxAttempts {
 				if waitErr := c.waitRetry(ctx, attempt); waitErr != nil {
-					return waitErr
+					return nil, waitErr
 				}
 				continue
 			}
-			return fmt.Errorf("request failed: %w", err)
+			return nil, fmt.Errorf("request failed: %w", err)
 		}
 
 		if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
 			snippet, _ := io.ReadAll(io.LimitReader(res.Body, 8<<10))
 			_ = res.Body.Close()
+			retryAfter := parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+			limit := c.governor.release(res.StatusCode, retryAfter)
+			event.Status, event.Latency, event.Bytes = res.StatusCode, time.Since(start), len(snippet)
+			c.emit(ctx, event, "concurrency", limit, "retry_after", retryAfter)
 
 			apiErr := &APIError{
 				StatusCode: res.StatusCode,
@@ -206,26 +318,59 @@ func (c *Client) getJSON(ctx context.Context, endpoint string, out any) error {
 			}
 			if c.shouldRetryStatus(res.StatusCode) && attempt < maxAttempts {
 				if waitErr := c.waitRetry(ctx, attempt); waitErr != nil {
-					return waitErr
+					return nil, waitErr
 				}
 				continue
 			}
-			return apiErr
+			return nil, apiErr
 		}
 
-		dec := json.NewDecoder(res.Body)
-		err = dec.Decode(out)
+		body, err := io.ReadAll(res.Body)
 		_ = res.Body.Close()
+		c.governor.release(res.StatusCode, 0)
+		event.Status, event.Latency, event.Bytes = res.StatusCode, time.Since(start), len(body)
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				return nil
-			}
-			return fmt.Errorf("decode response from %s: %w", endpoint, err)
+			event.Err = err.Error()
+			c.emit(ctx, event)
+			return nil, fmt.Errorf("read response from %s: %w", endpoint, err)
 		}
-		return nil
+		c.emit(ctx, event)
+		return body, nil
 	}
 
-	return errors.New("request failed after retries")
+	return nil, errors.New("request failed after retries")
+}
+
+// emit hands event to the observers and, with WithLogger, logs the same
+// event: one line per attempt, at warn level when the attempt failed. args
+// are extra attributes for the log line only.
+func (c *Client) emit(ctx context.Context, event RequestEvent, args ...any) {
+	Emit(event)
+	if c.logger == nil {
+		return
+	}
+	level := slog.LevelDebug
+	attrs := []any{"url", redactURL(event.URL)}
+	if event.Cache != "" {
+		attrs = append(attrs, "cache", event.Cache)
+	}
+	if event.Attempt > 0 {
+		attrs = append(attrs, "attempt", event.Attempt)
+	}
+	if event.Status > 0 {
+		attrs = append(attrs, "status", event.Status)
+		if event.Status < http.StatusOK || event.Status >= http.StatusMultipleChoices {
+			level = slog.LevelWarn
+		}
+	}
+	if event.Latency > 0 {
+		attrs = append(attrs, "latency", event.Latency)
+	}
+	if event.Err != "" {
+		attrs = append(attrs, "error", event.Err)
+		level = slog.LevelWarn
+	}
+	c.logger.Log(ctx, level, "request", append(attrs, args...)...)
 }
 
 func (c *Client) shouldRetryStatus(code int) bool {
@@ -236,7 +381,7 @@ func (c *Client) shouldRetryNetworkError(err error) bool {
 	if err == nil {
 		return false
 	}
-	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, ErrOffline)
 }
 
 func (c *Client) waitRetry(ctx context.Context, attempt int) error {
@@ -266,14 +411,11 @@ func (c *Client) retryDelay(attempt int) time.Duration {
 	}
 
 	delay := base
-	for i := 1; i < attempt; i++ {
-		if delay >= cap/2 {
-			return cap
-		}
+	for i := 1; i < attempt && delay < cap; i++ {
 		delay *= 2
 	}
-	if delay > cap {
-		return cap
-	}
-	return delay
+	delay = min(delay, cap)
+	// Equal jitter: keep half of the backoff and randomize the rest, so
+	// clients failing together do not retry together.
+	return delay/2 + rand.N(delay/2+1)
 }
@@ -279,3 +279,28 @@ func TestGetSeatMap_OK(t *testing.T) {
 		t.Fatalf("unexpected bounds: %+v", seats.Bounds)
 	}
 }
+
+func TestGetSectionTickets_OK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sessions/123/sections/456/tickets" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+  {"id": "1", "name": "Inteira", "price": 40.0, "service": 6.0, "total": 46.0},
+  {"id": "2", "name": "Meia-Entrada", "price": 20.0, "service": 3.0, "total": 23.0}
+]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.checkoutURL = server.URL
+
+	tickets, err := client.GetSectionTickets(context.Background(), "123", "456")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(tickets) != 2 || tickets[1].Name != "Meia-Entrada" || tickets[1].Total != 23 {
+		t.Fatalf("unexpected tickets: %+v", tickets)
+	}
+}
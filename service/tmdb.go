@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tmdbBaseURL  = "https://api.themoviedb.org/3"
+	tmdbTimeout  = 5 * time.Second
+	tmdbLanguage = "pt-BR"
+	// tmdbPosterURL serves posters 342 pixels wide, plenty for a terminal.
+	tmdbPosterURL = "https://image.tmdb.org/t/p/w342"
+	// tmdbCastSize is how many billed actors MovieMetadata keeps.
+	tmdbCastSize = 5
+)
+
+// TMDbOption configures a TMDbClient.
+type TMDbOption func(*TMDbClient)
+
+// WithTMDbBaseURL points the client at baseURL instead of api.themoviedb.org.
+func WithTMDbBaseURL(baseURL string) TMDbOption {
+	return func(c *TMDbClient) {
+		if baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/"); baseURL != "" {
+			c.baseURL = baseURL
+		}
+	}
+}
+
+// TMDbClient looks up Portuguese synopses, cast and trailers on The Movie
+// Database, whose pt-BR titles match the ones Ingresso lists.
+type TMDbClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+}
+
+// NewTMDbClient creates a client using apiKey, either a v3 API key or a v4
+// read access token. If httpClient is nil, a default client is used.
+func NewTMDbClient(apiKey string, httpClient *http.Client, opts ...TMDbOption) *TMDbClient {
+	if httpClient == nil {
+		httpClient = newHTTPClient(tmdbTimeout)
+	}
+	c := &TMDbClient{httpClient: httpClient, baseURL: tmdbBaseURL, apiKey: strings.TrimSpace(apiKey)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// NewTMDbClientFromEnv reads the key from TMDB_API_KEY and an optional base URL
+// from TMDB_API_URL.
+func NewTMDbClientFromEnv() *TMDbClient {
+	return NewTMDbClient(os.Getenv("TMDB_API_KEY"), nil, WithTMDbBaseURL(os.Getenv("TMDB_API_URL")))
+}
+
+// Name implements MetadataProvider.
+func (c *TMDbClient) Name() string {
+	return "tmdb"
+}
+
+// Enabled reports whether the client has an API key.
+func (c *TMDbClient) Enabled() bool {
+	return c != nil && c.apiKey != ""
+}
+
+type tmdbSearchResponse struct {
+	Results []struct {
+		ID          int    `json:"id"`
+		ReleaseDate string `json:"release_date"`
+	} `json:"results"`
+}
+
+type tmdbFindResponse struct {
+	MovieResults []struct {
+		ID int `json:"id"`
+	} `json:"movie_results"`
+}
+
+type tmdbMovie struct {
+	ImdbID      string  `json:"imdb_id"`
+	Title       string  `json:"title"`
+	Overview    string  `json:"overview"`
+	PosterPath  string  `json:"poster_path"`
+	ReleaseDate string  `json:"release_date"`
+	Runtime     int     `json:"runtime"`
+	VoteAverage float64 `json:"vote_average"`
+	VoteCount   int     `json:"vote_count"`
+	Genres      []struct {
+		Name string `json:"name"`
+	} `json:"genres"`
+	Credits struct {
+		Cast []struct {
+			Name string `json:"name"`
+		} `json:"cast"`
+		Crew []struct {
+			Name string `json:"name"`
+			Job  string `json:"job"`
+		} `json:"crew"`
+	} `json:"credits"`
+	Videos struct {
+		Results []struct {
+			Key  string `json:"key"`
+			Site string `json:"site"`
+			Type string `json:"type"`
+		} `json:"results"`
+	} `json:"videos"`
+}
+
+// MovieMetadata implements MetadataProvider. A query pinned to an IMDb id is
+// looked up by that id. Otherwise the Portuguese title is searched first, then
+// the original one; the results closest to the expected year are loaded and
+// the one that best matches year and runtime wins.
+func (c *TMDbClient) MovieMetadata(ctx context.Context, query MovieQuery) (MovieMetadata, error) {
+	if !c.Enabled() {
+		return MovieMetadata{}, fmt.Errorf("TMDB_API_KEY environment variable is not set")
+	}
+	if query.ImdbID != "" {
+		movie, err := c.findByImdbID(ctx, query.ImdbID)
+		if err != nil {
+			return MovieMetadata{}, err
+		}
+		return movie.metadata(), nil
+	}
+
+	var titles []string
+	cleanPt := cleanTitleForSearch(query.Title)
+	cleanOrig := cleanTitleForSearch(query.OriginalTitle)
+	if cleanPt != "" {
+		titles = append(titles, cleanPt)
+	}
+	if cleanOrig != "" && cleanOrig != cleanPt {
+		titles = append(titles, cleanOrig)
+	}
+	if len(titles) == 0 {
+		return MovieMetadata{}, fmt.Errorf("no valid title provided for search")
+	}
+
+	var lastErr error
+	for _, title := range titles {
+		movie, err := c.search(ctx, title, query)
+		if err == nil {
+			return movie.metadata(), nil
+		}
+		if !errors.Is(err, ErrMovieNotFound) {
+			lastErr = err
+		}
+	}
+	if lastErr != nil {
+		return MovieMetadata{}, lastErr
+	}
+	return MovieMetadata{}, ErrMovieNotFound
+}
+
+func (c *TMDbClient) search(ctx context.Context, title string, query MovieQuery) (*tmdbMovie, error) {
+	var results tmdbSearchResponse
+	if err := c.get(ctx, "/search/movie", url.Values{"query": {title}}, &results); err != nil {
+		return nil, err
+	}
+	candidates := results.Results
+	if len(candidates) == 0 {
+		return nil, ErrMovieNotFound
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return yearDiff(query.Year, candidates[i].ReleaseDate) < yearDiff(query.Year, candidates[j].ReleaseDate)
+	})
+	if len(candidates) > maxSearchCandidates {
+		candidates = candidates[:maxSearchCandidates]
+	}
+
+	var best *tmdbMovie
+	bestScore := 0
+	var lastErr error
+	for _, candidate := range candidates {
+		details, err := c.details(ctx, candidate.ID)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		score := matchScore(query, details.ReleaseDate, strconv.Itoa(details.Runtime))
+		if best == nil || score < bestScore {
+			best, bestScore = details, score
+		}
+	}
+	if best == nil {
+		return nil, lastErr
+	}
+	return best, nil
+}
+
+// findByImdbID loads the TMDb movie linked to an IMDb id.
+func (c *TMDbClient) findByImdbID(ctx context.Context, imdbID string) (*tmdbMovie, error) {
+	var found tmdbFindResponse
+	if err := c.get(ctx, "/find/"+url.PathEscape(imdbID), url.Values{"external_source": {"imdb_id"}}, &found); err != nil {
+		return nil, err
+	}
+	if len(found.MovieResults) == 0 {
+		return nil, ErrMovieNotFound
+	}
+	return c.details(ctx, found.MovieResults[0].ID)
+}
+
+// details loads a TMDb movie with its credits and trailers.
+func (c *TMDbClient) details(ctx context.Context, id int) (*tmdbMovie, error) {
+	var movie tmdbMovie
+	params := url.Values{"append_to_response": {"credits,videos"}, "include_video_language": {"pt,en"}}
+	if err := c.get(ctx, "/movie/"+strconv.Itoa(id), params, &movie); err != nil {
+		return nil, err
+	}
+	return &movie, nil
+}
+
+// get sends one TMDb request in Portuguese and decodes its body into out.
+func (c *TMDbClient) get(ctx context.Context, path string, params url.Values, out any) error {
+	reqURL, err := url.Parse(c.baseURL + path)
+	if err != nil {
+		return err
+	}
+	q := reqURL.Query()
+	for key, values := range params {
+		q[key] = values
+	}
+	q.Set("language", tmdbLanguage)
+	// v4 read access tokens are JWTs; anything else is a v3 API key.
+	bearer := strings.HasPrefix(c.apiKey, "eyJ")
+	if !bearer {
+		q.Set("api_key", c.apiKey)
+	}
+	reqURL.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearer {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	return getMetadataJSON(c.httpClient, SourceTMDb, req, out)
+}
+
+func (m *tmdbMovie) metadata() MovieMetadata {
+	metadata := MovieMetadata{
+		Provider: "tmdb",
+		ImdbID:   m.ImdbID,
+		Title:    m.Title,
+		Plot:     m.Overview,
+	}
+	if m.PosterPath != "" {
+		metadata.Poster = tmdbPosterURL + m.PosterPath
+	}
+	if len(m.ReleaseDate) >= 4 {
+		metadata.Year = m.ReleaseDate[:4]
+	}
+	if m.VoteCount > 0 {
+		metadata.TMDbRating = strconv.FormatFloat(m.VoteAverage, 'f', 1, 64)
+	}
+	var genres []string
+	for _, genre := range m.Genres {
+		genres = append(genres, genre.Name)
+	}
+	metadata.Genre = strings.Join(genres, ", ")
+	var directors []string
+	for _, member := range m.Credits.Crew {
+		if member.Job == "Director" {
+			directors = append(directors, member.Name)
+		}
+	}
+	metadata.Director = strings.Join(directors, ", ")
+	for _, actor := range m.Credits.Cast {
+		if len(metadata.Cast) == tmdbCastSize {
+			break
+		}
+		metadata.Cast = append(metadata.Cast, actor.Name)
+	}
+	for _, video := range m.Videos.Results {
+		if video.Site == "YouTube" && video.Type == "Trailer" {
+			metadata.Trailer = "https://www.youtube.com/watch?v=" + video.Key
+			break
+		}
+	}
+	return metadata
+}
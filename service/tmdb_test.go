@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTMDbMovieMetadata_PicksClosestRelease(t *testing.T) {
+	var gotLanguage string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		gotLanguage = r.URL.Query().Get("language")
+		switch r.URL.Path {
+		case "/search/movie":
+			_, _ = w.Write([]byte(`{"results":[{"id":1,"release_date":"1922-03-04"},{"id":2,"release_date":"2024-12-25"}]}`))
+		case "/movie/1":
+			_, _ = w.Write([]byte(`{"title":"Nosferatu","release_date":"1922-03-04","runtime":94,"overview":"Original."}`))
+		case "/movie/2":
+			_, _ = w.Write([]byte(`{
+				"title":"Nosferatu","release_date":"2024-12-25","runtime":132,"overview":"Uma jovem assombrada.","poster_path":"/nosferatu.jpg",
+				"vote_average":7.18,"vote_count":900,"genres":[{"name":"Terror"}],
+				"credits":{"cast":[{"name":"Lily-Rose Depp"},{"name":"Bill Skarsgård"}],"crew":[{"name":"Robert Eggers","job":"Director"}]},
+				"videos":{"results":[{"key":"abc","site":"YouTube","type":"Teaser"},{"key":"xyz","site":"YouTube","type":"Trailer"}]}
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewTMDbClient("secret", server.Client(), WithTMDbBaseURL(server.URL))
+	metadata, err := client.MovieMetadata(context.Background(), MovieQuery{Title: "Nosferatu - Legendado", Year: 2025, Runtime: 133})
+	if err != nil {
+		t.Fatalf("MovieMetadata() error = %v", err)
+	}
+	if metadata.Plot != "Uma jovem assombrada." || metadata.Year != "2024" {
+		t.Fatalf("MovieMetadata() = %+v, want the 2024 remake", metadata)
+	}
+	if metadata.Director != "Robert Eggers" || len(metadata.Cast) != 2 || metadata.TMDbRating != "7.2" {
+		t.Fatalf("MovieMetadata() = %+v, want credits and rating", metadata)
+	}
+	if metadata.Trailer != "https://www.youtube.com/watch?v=xyz" {
+		t.Fatalf("Trailer = %q, want the YouTube trailer", metadata.Trailer)
+	}
+	if metadata.Poster != "https://image.tmdb.org/t/p/w342/nosferatu.jpg" {
+		t.Fatalf("Poster = %q, want the w342 poster URL", metadata.Poster)
+	}
+	if gotLanguage != "pt-BR" {
+		t.Fatalf("language = %q, want pt-BR", gotLanguage)
+	}
+}
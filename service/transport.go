@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	transportMu      sync.RWMutex
+	defaultTransport http.RoundTripper
+)
+
+// SetDefaultTransport makes the clients this package builds on its own use rt:
+// NewClient(nil), NewOMDbClient(key, nil), NewTMDbClient(key, nil),
+// DetectCurrentLocation(ctx, nil) and FetchPoster(ctx, nil, url). A nil rt
+// restores http.DefaultTransport. Callers that pass their own *http.Client are
+// not affected.
+func SetDefaultTransport(rt http.RoundTripper) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	defaultTransport = rt
+}
+
+// ErrOffline is returned by OfflineTransport for every request.
+var ErrOffline = errors.New("offline mode: network access is disabled")
+
+// OfflineTransport returns a RoundTripper that fails every request with
+// ErrOffline without touching the network. Requests failing this way are not
+// retried.
+func OfflineTransport() http.RoundTripper {
+	return offlineTransport{}
+}
+
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, ErrOffline
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	transportMu.RLock()
+	defer transportMu.RUnlock()
+	return &http.Client{Timeout: timeout, Transport: defaultTransport}
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config is the optional config.json in the user config directory.
+type Config struct {
+	API      APIConfig      `json:"api"`
+	Metadata MetadataConfig `json:"metadata"`
+}
+
+// APIConfig overrides the Ingresso endpoints and HTTP behavior. Durations use
+// Go syntax such as "12s" or "250ms"; empty fields keep the defaults.
+type APIConfig struct {
+	BaseURL     string `json:"base_url"`
+	CheckoutURL string `json:"checkout_url"`
+	UserAgent   string `json:"user_agent"`
+	MaxAttempts int    `json:"max_attempts"`
+	Timeout     string `json:"timeout"`
+	RetryBase   string `json:"retry_base"`
+	RetryCap    string `json:"retry_cap"`
+}
+
+// MetadataConfig picks the movie metadata providers. Providers lists "omdb"
+// and "tmdb" in priority order; empty keeps the default.
+type MetadataConfig struct {
+	Providers []string `json:"providers"`
+}
+
+// ConfigPath returns where LoadConfig looks for the config file.
+func ConfigPath() (string, error) {
+	return configPath("config.json")
+}
+
+// LoadConfig reads config.json, returning an empty Config when it does not exist.
+func LoadConfig() (Config, error) {
+	var cfg Config
+	path, err := ConfigPath()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"ingresso-finder-cli/model"
+)
+
+// CityGroup is a named set of cities searched together, like a metropolitan
+// area.
+type CityGroup struct {
+	Name   string       `json:"name"`
+	Cities []model.City `json:"cities"`
+}
+
+// CityIDs returns the ids of the group's cities, in the order they were saved.
+func (g CityGroup) CityIDs() []string {
+	ids := make([]string, 0, len(g.Cities))
+	for _, city := range g.Cities {
+		ids = append(ids, city.Id)
+	}
+	return ids
+}
+
+type cityGroups struct {
+	Groups []CityGroup `json:"groups"`
+}
+
+// LoadCityGroups reads the city groups from the config directory, sorted by
+// name.
+func LoadCityGroups() ([]CityGroup, error) {
+	path, err := configPath("city_groups.json")
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var groups cityGroups
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return nil, errors.New("invalid city groups format")
+	}
+	return groups.Groups, nil
+}
+
+// FindCityGroup looks a group up by name, ignoring case.
+func FindCityGroup(name string) (CityGroup, bool, error) {
+	groups, err := LoadCityGroups()
+	if err != nil {
+		return CityGroup{}, false, err
+	}
+	for _, group := range groups {
+		if strings.EqualFold(group.Name, strings.TrimSpace(name)) {
+			return group, true, nil
+		}
+	}
+	return CityGroup{}, false, nil
+}
+
+// SaveCityGroup adds the group, replacing any group with the same name.
+func SaveCityGroup(group CityGroup) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" {
+		return errors.New("group name is required")
+	}
+	if len(group.Cities) == 0 {
+		return errors.New("a group needs at least one city")
+	}
+
+	groups, err := LoadCityGroups()
+	if err != nil {
+		return err
+	}
+	next := []CityGroup{group}
+	for _, existing := range groups {
+		if !strings.EqualFold(existing.Name, group.Name) {
+			next = append(next, existing)
+		}
+	}
+	return saveCityGroups(next)
+}
+
+// DeleteCityGroup removes the group with this name and reports whether it
+// existed.
+func DeleteCityGroup(name string) (bool, error) {
+	groups, err := LoadCityGroups()
+	if err != nil {
+		return false, err
+	}
+	next := make([]CityGroup, 0, len(groups))
+	for _, existing := range groups {
+		if !strings.EqualFold(existing.Name, strings.TrimSpace(name)) {
+			next = append(next, existing)
+		}
+	}
+	if len(next) == len(groups) {
+		return false, nil
+	}
+	return true, saveCityGroups(next)
+}
+
+func saveCityGroups(groups []CityGroup) error {
+	sort.Slice(groups, func(i, j int) bool {
+		return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+	})
+	path, err := configPath("city_groups.json")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	payload, err := json.MarshalIndent(cityGroups{Groups: groups}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, payload, 0o644)
+}
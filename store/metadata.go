@@ -0,0 +1,79 @@
+package store
+
+import (
+	"time"
+)
+
+const metadataCacheTTL = 30 * 24 * time.Hour // 30 dias de cache, pois metadados de filmes mudam raramente
+
+// MovieMetadata representa os dados essenciais que queremos salvar do filme,
+// venham eles do OMDb, do TMDb ou de ambos
+type MovieMetadata struct {
+	Provider   string   `json:"provider"`
+	ImdbRating string   `json:"imdbRating"`
+	Metascore  string   `json:"metascore"`
+	Rotten     string   `json:"rotten"`
+	TMDbRating string   `json:"tmdbRating,omitempty"`
+	Genre      string   `json:"genre"`
+	Director   string   `json:"director"`
+	Plot       string   `json:"plot"`
+	Cast       []string `json:"cast,omitempty"`
+	Trailer    string   `json:"trailer,omitempty"`
+	Poster     string   `json:"poster,omitempty"`
+	NotFound   bool     `json:"not_found"`
+}
+
+type metadataCacheMap map[string]MovieMetadata
+
+// metadataKey separa os caches de cada combinação de provedores, para que
+// trocar de provedor não reaproveite respostas do anterior
+func metadataKey(provider, title string) string {
+	return provider + "/" + title
+}
+
+// LoadMovieMetadata busca as informações do filme no cache local
+func LoadMovieMetadata(provider, title string) (MovieMetadata, bool) {
+	path, err := cachePath("movie_metadata.json")
+	if err != nil {
+		return MovieMetadata{}, false
+	}
+
+	cache, err := loadCache[metadataCacheMap](path)
+	if err != nil || cache.Data == nil {
+		return MovieMetadata{}, false
+	}
+
+	// Como notas não expiram do dia para a noite, podemos usar um TTL longo
+	if time.Since(cache.UpdatedAt) > metadataCacheTTL {
+		return MovieMetadata{}, false
+	}
+
+	metadata, exists := cache.Data[metadataKey(provider, title)]
+	return metadata, exists
+}
+
+// SaveMovieMetadata atualiza o dicionário local com os dados recém-buscados
+func SaveMovieMetadata(provider, title string, metadata MovieMetadata) error {
+	path, err := cachePath("movie_metadata.json")
+	if err != nil {
+		return err
+	}
+
+	cache, err := loadCache[metadataCacheMap](path)
+	var data metadataCacheMap
+
+	if err == nil && cache.Data != nil {
+		// Se o cache já passou do TTL, nós limpamos e começamos um novo para evitar lixo eterno
+		if time.Since(cache.UpdatedAt) > metadataCacheTTL {
+			data = make(metadataCacheMap)
+		} else {
+			data = cache.Data
+		}
+	} else {
+		data = make(metadataCacheMap)
+	}
+
+	data[metadataKey(provider, title)] = metadata
+
+	return saveCache(path, data)
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// PosterPath devolve onde fica o pôster baixado de url. O sufixo distingue
+// arquivos derivados do original, como "png" para a cópia enviada ao kitty
+func PosterPath(url, suffix string) (string, error) {
+	sum := sha256.Sum256([]byte(url))
+	ext := path.Ext(url)
+	if suffix != "" {
+		ext = "." + suffix
+	}
+	if ext == "" || len(ext) > 5 {
+		ext = ".img"
+	}
+	return cachePath(filepath.Join("posters", hex.EncodeToString(sum[:8])+ext))
+}
+
+// LoadPoster lê o pôster do cache local. Pôsteres não expiram, pois a URL muda
+// quando a imagem muda
+func LoadPoster(url string) ([]byte, bool) {
+	path, err := PosterPath(url, "")
+	if err != nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+	return data, true
+}
+
+// SavePoster grava o pôster baixado no cache local
+func SavePoster(url string, data []byte) error {
+	path, err := PosterPath(url, "")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
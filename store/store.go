@@ -17,6 +17,7 @@ const (
 	cityCacheTTL     = 7 * 24 * time.Hour
 	theaterCacheTTL  = 72 * time.Hour
 	sessionCacheTTL  = 10 * time.Minute
+	eventCacheTTL    = 24 * time.Hour
 	maxRecentCities  = 8
 	maxRecentTheater = 8
 )
@@ -26,6 +27,18 @@ type cacheEnvelope[T any] struct {
 	Data      T         `json:"data"`
 }
 
+// CacheInfo describes a cache entry: when it was saved and whether it is still
+// within its TTL. Stale entries are kept so they can be served when the network
+// is unavailable.
+type CacheInfo struct {
+	UpdatedAt time.Time
+	Fresh     bool
+}
+
+func cacheInfo(updatedAt time.Time, ttl time.Duration) CacheInfo {
+	return CacheInfo{UpdatedAt: updatedAt, Fresh: time.Since(updatedAt) <= ttl}
+}
+
 type RecentCity struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -50,16 +63,16 @@ type theaterVisibility struct {
 	HiddenByCity map[string][]string `json:"hidden_by_city"`
 }
 
-func LoadCityCache() ([]model.City, bool, error) {
+func LoadCityCache() ([]model.City, CacheInfo, error) {
 	path, err := cachePath("cities.json")
 	if err != nil {
-		return nil, false, err
+		return nil, CacheInfo{}, err
 	}
 	cache, err := loadCache[[]model.City](path)
 	if err != nil {
-		return nil, false, err
+		return nil, CacheInfo{}, err
 	}
-	return cache.Data, time.Since(cache.UpdatedAt) <= cityCacheTTL, nil
+	return cache.Data, cacheInfo(cache.UpdatedAt, cityCacheTTL), nil
 }
 
 func SaveCityCache(cities []model.City) error {
@@ -70,16 +83,16 @@ func SaveCityCache(cities []model.City) error {
 	return saveCache(path, cities)
 }
 
-func LoadTheaterCache(cityID string) ([]model.Theater, bool, error) {
+func LoadTheaterCache(cityID string) ([]model.Theater, CacheInfo, error) {
 	path, err := cachePath(fmt.Sprintf("theaters_%s.json", cityID))
 	if err != nil {
-		return nil, false, err
+		return nil, CacheInfo{}, err
 	}
 	cache, err := loadCache[[]model.Theater](path)
 	if err != nil {
-		return nil, false, err
+		return nil, CacheInfo{}, err
 	}
-	return cache.Data, time.Since(cache.UpdatedAt) <= theaterCacheTTL, nil
+	return cache.Data, cacheInfo(cache.UpdatedAt, theaterCacheTTL), nil
 }
 
 func SaveTheaterCache(cityID string, theaters []model.Theater) error {
@@ -90,16 +103,16 @@ func SaveTheaterCache(cityID string, theaters []model.Theater) error {
 	return saveCache(path, theaters)
 }
 
-func LoadSessionCache(cityID string, theaterID string, date string) ([]model.TheaterSessionDay, bool, error) {
+func LoadSessionCache(cityID string, theaterID string, date string) ([]model.TheaterSessionDay, CacheInfo, error) {
 	path, err := cachePath(fmt.Sprintf("sessions_%s_%s_%s.json", cityID, theaterID, date))
 	if err != nil {
-		return nil, false, err
+		return nil, CacheInfo{}, err
 	}
 	cache, err := loadCache[[]model.TheaterSessionDay](path)
 	if err != nil {
-		return nil, false, err
+		return nil, CacheInfo{}, err
 	}
-	return cache.Data, time.Since(cache.UpdatedAt) <= sessionCacheTTL, nil
+	return cache.Data, cacheInfo(cache.UpdatedAt, sessionCacheTTL), nil
 }
 
 func SaveSessionCache(cityID string, theaterID string, date string, days []model.TheaterSessionDay) error {
@@ -110,6 +123,26 @@ func SaveSessionCache(cityID string, theaterID string, date string, days []model
 	return saveCache(path, days)
 }
 
+func LoadEventCache(eventID string) (model.Event, CacheInfo, error) {
+	path, err := cachePath(fmt.Sprintf("event_%s.json", eventID))
+	if err != nil {
+		return model.Event{}, CacheInfo{}, err
+	}
+	cache, err := loadCache[model.Event](path)
+	if err != nil {
+		return model.Event{}, CacheInfo{}, err
+	}
+	return cache.Data, cacheInfo(cache.UpdatedAt, eventCacheTTL), nil
+}
+
+func SaveEventCache(eventID string, event model.Event) error {
+	path, err := cachePath(fmt.Sprintf("event_%s.json", eventID))
+	if err != nil {
+		return err
+	}
+	return saveCache(path, event)
+}
+
 func LoadRecentCities() ([]RecentCity, error) {
 	path, err := configPath("history.json")
 	if err != nil {
@@ -206,19 +239,27 @@ func RememberTheater(cityID string, theater model.Theater) error {
 	return saveRecentTheaters(next)
 }
 
-func LoadHiddenTheaters(cityID string) (map[string]bool, error) {
+// LoadHiddenTheaters junta os cinemas ocultos de cada cidade informada; a
+// preferência continua salva por cidade, mesmo quando um grupo é pesquisado
+func LoadHiddenTheaters(cityIDs ...string) (map[string]bool, error) {
 	result := map[string]bool{}
-	if strings.TrimSpace(cityID) == "" {
-		return result, nil
-	}
-
-	visibility, err := loadTheaterVisibility()
-	if err != nil {
-		return nil, err
-	}
-	for _, theaterID := range visibility.HiddenByCity[cityID] {
-		if theaterID != "" {
-			result[theaterID] = true
+	var visibility theaterVisibility
+	loaded := false
+	for _, cityID := range cityIDs {
+		if strings.TrimSpace(cityID) == "" {
+			continue
+		}
+		if !loaded {
+			var err error
+			if visibility, err = loadTheaterVisibility(); err != nil {
+				return nil, err
+			}
+			loaded = true
+		}
+		for _, theaterID := range visibility.HiddenByCity[cityID] {
+			if theaterID != "" {
+				result[theaterID] = true
+			}
 		}
 	}
 	return result, nil
@@ -373,7 +414,19 @@ func configPath(name string) (string, error) {
 	return filepath.Join(dir, "ingresso-finder-cli", name), nil
 }
 
+// cacheDirOverride replaces the user cache directory when set by SetCacheDir.
+var cacheDirOverride string
+
+// SetCacheDir stores the API caches under dir instead of the user cache
+// directory. An empty dir restores the default. Call it before any cache access.
+func SetCacheDir(dir string) {
+	cacheDirOverride = dir
+}
+
 func cachePath(name string) (string, error) {
+	if cacheDirOverride != "" {
+		return filepath.Join(cacheDirOverride, name), nil
+	}
 	dir, err := os.UserCacheDir()
 	if err != nil {
 		return "", err
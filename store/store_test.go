@@ -1,6 +1,10 @@
 package store
 
-import "testing"
+import (
+	"testing"
+
+	"ingresso-finder-cli/model"
+)
 
 func setTestConfigDir(t *testing.T) {
 	t.Helper()
@@ -60,3 +64,61 @@ func TestSetTheaterHidden_InvalidInput(t *testing.T) {
 		t.Fatal("expected error for empty theater id")
 	}
 }
+
+func TestLoadHiddenTheaters_MergesCities(t *testing.T) {
+	setTestConfigDir(t)
+
+	if err := SetTheaterHidden("1", "10", true); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := SetTheaterHidden("2", "20", true); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	hidden, err := LoadHiddenTheaters("1", "2")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if !hidden["10"] || !hidden["20"] {
+		t.Fatalf("expected both cities' hidden theaters, got %+v", hidden)
+	}
+	if hidden, _ := LoadHiddenTheaters("2"); hidden["10"] {
+		t.Fatalf("expected city 1 preferences to stay in city 1, got %+v", hidden)
+	}
+}
+
+func TestCityGroups_RoundTrip(t *testing.T) {
+	setTestConfigDir(t)
+
+	group := CityGroup{Name: "Grande SP", Cities: []model.City{{Id: "1", Name: "São Paulo"}, {Id: "2", Name: "Guarulhos"}}}
+	if err := SaveCityGroup(group); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := SaveCityGroup(CityGroup{Name: "ABC", Cities: []model.City{{Id: "3", Name: "Santo André"}}}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	group.Cities = append(group.Cities, model.City{Id: "4", Name: "Osasco"})
+	if err := SaveCityGroup(group); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	groups, err := LoadCityGroups()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(groups) != 2 || groups[0].Name != "ABC" || len(groups[1].Cities) != 3 {
+		t.Fatalf("expected the replaced group sorted by name, got %+v", groups)
+	}
+
+	found, ok, err := FindCityGroup("grande sp")
+	if err != nil || !ok || found.Name != "Grande SP" {
+		t.Fatalf("expected to find the group ignoring case, got %+v %v %v", found, ok, err)
+	}
+
+	if deleted, err := DeleteCityGroup("ABC"); err != nil || !deleted {
+		t.Fatalf("expected the group to be deleted, got %v %v", deleted, err)
+	}
+	if err := SaveCityGroup(CityGroup{Name: "Vazio"}); err == nil {
+		t.Fatal("expected an error for a group without cities")
+	}
+}
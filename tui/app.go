@@ -4,15 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/ics"
 	"ingresso-finder-cli/model"
 	"ingresso-finder-cli/service"
 	"ingresso-finder-cli/store"
@@ -23,13 +25,45 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-func New() tea.Model {
-	client := service.NewClient(nil)
+// Option configures the model built by New.
+type Option func(*appModel)
+
+// WithClient makes the TUI use client instead of a default one.
+func WithClient(client *service.Client) Option {
+	return func(m *appModel) {
+		m.client = client
+	}
+}
+
+// WithMetadataProvider makes the TUI look up ratings and synopses with
+// provider instead of TMDb and OMDb configured from the environment.
+func WithMetadataProvider(provider service.MetadataProvider) Option {
+	return func(m *appModel) {
+		m.metadata = provider
+	}
+}
+
+func New(opts ...Option) tea.Model {
 	m := appModel{
-		client: client,
-		state:  stateLoadingCities,
-		date:   truncateDate(time.Now()),
+		state: stateLoadingCities,
+		date:  truncateDate(time.Now()),
+		now:   time.Now,
 	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	if m.client == nil {
+		m.client = service.NewClient(nil)
+	}
+	if m.metadata == nil {
+		// main reports configuration errors; the TUI then runs without metadata.
+		if chain, err := finder.NewMetadataProvider(); err == nil {
+			m.metadata = chain
+		} else {
+			m.metadata = service.NewMetadataChain()
+		}
+	}
+	m.finder = finder.New(m.client)
 
 	m.cityList = newList("Select City")
 	m.theaterList = newList("Theaters")
@@ -38,10 +72,17 @@ func New() tea.Model {
 	m.sessionList = newList("Sessions")
 	m.sectionList = newList("Select Section")
 	m.dateList = newList("Select Date")
+	m.dateList.SetDelegate(newDateDelegate())
 
 	m.showSeatNumbers = true
+	m.seatWatchSize = defaultSeatWatchSize
 	m.seatCounts = make(map[string]seatCount)
-	m.movieRatings = make(map[string]store.OMDbRating)
+	m.sessionPrices = make(map[string]sessionPrices)
+	m.ticketMix = defaultTicketMix()
+	m.movieMetadata = make(map[string]store.MovieMetadata)
+	m.posters = make(map[string]string)
+	m.failedPosters = make(map[string]bool)
+	m.posterMode = detectPosterMode()
 	m.hiddenTheaters = make(map[string]bool)
 
 	sp := spinner.New()
@@ -63,6 +104,14 @@ func (m appModel) Init() tea.Cmd {
 }
 
 func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if gen, ok := requestGenOf(msg); ok && gen != m.requestGen {
+		// Answer to a request from a screen the user already left.
+		return m, nil
+	}
+	if since, ok := staleSinceOf(msg); ok {
+		m.staleSince = since
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -74,6 +123,23 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		m.notice = ""
+		if msg.String() == "ctrl+x" && m.seatAlert != "" {
+			m.seatAlert = ""
+			return m, nil
+		}
+		if m.showRequests {
+			m, cmd, _ := m.handleRequestLogKey(msg)
+			return m, cmd
+		}
+		if m.editingMix {
+			m.handleTicketMixInput(msg)
+			return m, nil
+		}
+		if m.editingSeatLabels {
+			m.handleSeatLabelInput(msg)
+			return m, nil
+		}
 		if m.handleFilterInput(msg) {
 			if m.state == stateShowSessions {
 				return m, m.startSeatCountFetchForVisiblePage()
@@ -89,7 +155,7 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
-		if m.isLoadingState() {
+		if m.isLoadingState() || m.loadingMovieEvent() {
 			return m, cmd
 		}
 		return m, nil
@@ -105,9 +171,59 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = stateError
 		return m, nil
 
-	case omdbRatingMsg:
+	case noticeMsg:
+		m.notice = msg.text
+		return m, nil
+
+	case movieMetadataMsg:
+		if msg.err != nil {
+			return m, nil
+		}
+		m.movieMetadata[msg.title] = msg.metadata
+		if _, ok := m.posters[msg.title]; !ok {
+			return m, m.fetchPosterCmd(msg.title, msg.metadata.Poster)
+		}
+		return m, nil
+
+	case weekMsg:
+		if msg.err != nil {
+			return m, errCmd(msg.err)
+		}
+		m.week = msg.week
+		m.weekFailed = msg.failed
+		m.weekCursor = 0
+		m.state = stateShowWeek
+		return m, nil
+
+	case availableDaysMsg:
+		if msg.err != nil {
+			// The picker still offers every day when the schedule is unknown.
+			return m, nil
+		}
+		m.availableDays = msg.days
+		if m.state == stateSelectDate {
+			m.dateList.SetItems(buildDateItems(m.date, m.availableDays, m.rangeStart))
+		}
+		return m, nil
+
+	case eventMsg:
+		if msg.err != nil {
+			m.movieEventErr = msg.err
+			return m, nil
+		}
+		m.movieEvent = &msg.event
+		if item, ok := m.movieList.SelectedItem().(movieItem); ok {
+			if _, ok := m.posters[item.movie.Title]; !ok {
+				return m, m.fetchPosterCmd(item.movie.Title, eventPosterURL(msg.event))
+			}
+		}
+		return m, nil
+
+	case posterMsg:
 		if msg.err == nil {
-			m.movieRatings[msg.title] = msg.rating
+			m.posters[msg.title] = msg.art
+		} else if !errors.Is(msg.err, context.Canceled) {
+			m.failedPosters[msg.url] = true
 		}
 		return m, nil
 
@@ -116,16 +232,18 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, errCmd(msg.err)
 		}
 		m.cities = msg.cities
-		m.cityList.SetItems(buildCityItems(msg.cities))
+		m.cityList.SetItems(append(buildCityGroupItems(), buildCityItems(msg.cities)...))
 		m.state = stateSelectCity
 		return m, nil
 
 	case cityMsg:
+		m.beginRequests()
 		if msg.err != nil {
 			m.state = stateLoadingCities
 			return m, tea.Batch(m.fetchCitiesCmd(), m.spinner.Tick)
 		}
 		m.city = msg.city
+		m.cityGroup = store.CityGroup{}
 		m.theater = model.Theater{}
 		m.browsingAllTheaters = false
 		_ = store.RememberCity(m.city)
@@ -137,11 +255,14 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, errCmd(msg.err)
 		}
 		m.theaters = msg.theaters
-		hidden, err := store.LoadHiddenTheaters(m.city.Id)
+		hidden, err := store.LoadHiddenTheaters(m.cityIDs()...)
 		if err != nil {
 			return m, errCmd(err)
 		}
 		m.hiddenTheaters = hidden
+		if msg.failedCities > 0 {
+			m.notice = fmt.Sprintf("⚠️  %d cidades de %s não puderam ser carregadas", msg.failedCities, m.cityGroup.Name)
+		}
 		m.refreshTheaterLists()
 		m.theaterList.Select(0)
 		m.state = stateSelectTheater
@@ -160,12 +281,12 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			)
 		}
 		m.movieList.Title = "Select Movie"
-		m.movieList.SetItems(buildMovieItems(selectDay(m.days, m.date)))
+		m.movieList.SetItems(buildMovieItems(finder.SelectDay(m.days, m.date)))
 		m.state = stateSelectMovie
 		var cmd tea.Cmd
 		if m.movieList.SelectedItem() != nil {
 			if item, ok := m.movieList.SelectedItem().(movieItem); ok {
-				cmd = fetchMovieRatingCmd(item.movie.Title, item.movie.OriginalTitle)
+				cmd = m.fetchMovieMetadataCmd(item.movie)
 			}
 		}
 		return m, cmd
@@ -175,13 +296,13 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, errWithOptionsCmd(msg.err, stateSelectTheater, msg.noSessions)
 		}
 		m.browsingAllTheaters = true
-		m.movieList.Title = "Select Movie • All Theaters"
+		m.movieList.Title = catalogListTitle(msg.failed, msg.ignored)
 		m.movieList.SetItems(buildMovieItemsFromCatalog(msg.movies))
 		m.state = stateSelectMovie
 		var cmd tea.Cmd
 		if m.movieList.SelectedItem() != nil {
 			if item, ok := m.movieList.SelectedItem().(movieItem); ok {
-				cmd = fetchMovieRatingCmd(item.movie.Title, item.movie.OriginalTitle)
+				cmd = m.fetchMovieMetadataCmd(item.movie)
 			}
 		}
 		return m, cmd
@@ -203,11 +324,18 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case sessionPricesMsg:
+		m.sessionPrices[msg.sessionID] = msg.prices
+		if m.state == stateShowSessions {
+			return m, m.updateSessionPrices(msg.sessionID, msg.prices)
+		}
+		return m, nil
+
 	case sessionDetailsMsg:
 		if msg.err != nil {
 			return m, errCmd(msg.err)
 		}
-		sections := filterSeatSections(msg.detail.Sections)
+		sections := finder.SeatSections(msg.detail.Sections)
 		if len(sections) == 0 {
 			return m, errCmd(errors.New("no seat map available for this session"))
 		}
@@ -226,6 +354,41 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.seatMap = msg.seatMap
 		m.state = stateShowSeatMap
+		m.seatWatching = false
+		return m, nil
+
+	case requestLogTickMsg:
+		if !m.showRequests || msg.gen != m.requestLogGen {
+			return m, nil
+		}
+		return m, requestLogTickCmd(msg.gen)
+
+	case seatWatchTickMsg:
+		if !m.seatWatching || msg.gen != m.seatWatchGen || m.state != stateShowSeatMap {
+			return m, nil
+		}
+		return m, m.fetchSeatWatchCmd(msg.gen, m.selectedSession.Id, m.selectedSection.Id)
+
+	case seatWatchMsg:
+		if !m.seatWatching || msg.gen != m.seatWatchGen {
+			return m, nil
+		}
+		m.seatWatchChecked = time.Now()
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("⚠️  Falha ao atualizar assentos: %v", msg.err)
+			return m, seatWatchTickCmd(msg.gen)
+		}
+		m.seatMap = msg.seatMap
+		alert := m.seatWatch().Check(m.seatMap)
+		if !alert.Triggered() {
+			return m, seatWatchTickCmd(msg.gen)
+		}
+		m.seatWatching = false
+		if len(alert.Labels) > 0 {
+			m.seatAlert = "🔔 Assentos livres: " + strings.Join(alert.Labels, ", ")
+		} else {
+			m.seatAlert = fmt.Sprintf("🔔 %d lugares juntos livres em %s", m.seatWatchSize, finder.BlockLabel(alert.Blocks[0]))
+		}
 		return m, nil
 	}
 
@@ -264,7 +427,7 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if item, ok := m.movieList.SelectedItem().(movieItem); ok {
 			if oldSelectedTitle != item.movie.Title {
 				// Cursor mudou de filme, disparamos a busca em background
-				return m, tea.Batch(cmd, fetchMovieRatingCmd(item.movie.Title, item.movie.OriginalTitle))
+				return m, tea.Batch(cmd, m.fetchMovieMetadataCmd(item.movie))
 			}
 		}
 	}
@@ -272,51 +435,61 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-type omdbRatingMsg struct {
-	title  string
-	rating store.OMDbRating
-	err    error
+type movieMetadataMsg struct {
+	gen      int
+	title    string
+	metadata store.MovieMetadata
+	err      error
 }
 
-func fetchMovieRatingCmd(title, originalTitle string) tea.Cmd {
+// fetchMovieMetadataCmd looks movie up with the metadata providers, using the
+// selected date as the year hint and the announced duration to tell remakes
+// apart. It returns nil when no provider is configured.
+func (m appModel) fetchMovieMetadataCmd(movie model.TheaterMovie) tea.Cmd {
+	provider := m.metadata
+	if provider == nil || !provider.Enabled() {
+		return nil
+	}
+	name := provider.Name()
+	title := movie.Title
+	query := service.MovieQuery{Title: movie.Title, OriginalTitle: movie.OriginalTitle, Year: m.date.Year()}
+	query.Runtime, _ = strconv.Atoi(strings.TrimSpace(movie.Duration))
 	return func() tea.Msg {
 		// Verifica se já temos no cache
-		if rating, ok := store.LoadMovieRating(title); ok {
-			return omdbRatingMsg{title: title, rating: rating}
+		if metadata, ok := store.LoadMovieMetadata(name, title); ok {
+			service.Emit(service.RequestEvent{Source: name, URL: title, Cache: service.CacheStore})
+			return movieMetadataMsg{gen: m.requestGen, title: title, metadata: metadata}
 		}
 
-		// Tenta buscar na API
-		data, err := service.FetchMovieData(title, originalTitle)
+		// Tenta buscar nos provedores
+		data, err := provider.MovieMetadata(m.requestContext(), query)
 		if err != nil {
-			if strings.Contains(err.Error(), "Movie not found!") {
-				rating := store.OMDbRating{NotFound: true}
-				_ = store.SaveMovieRating(title, rating)
-				return omdbRatingMsg{title: title, rating: rating}
-			}
-			return omdbRatingMsg{title: title, err: err}
-		}
-
-		var rotten string
-		for _, r := range data.Ratings {
-			if r.Source == "Rotten Tomatoes" {
-				rotten = r.Value
-				break
+			if errors.Is(err, service.ErrMovieNotFound) {
+				metadata := store.MovieMetadata{Provider: name, NotFound: true}
+				_ = store.SaveMovieMetadata(name, title, metadata)
+				return movieMetadataMsg{gen: m.requestGen, title: title, metadata: metadata}
 			}
+			return movieMetadataMsg{gen: m.requestGen, title: title, err: err}
 		}
 
-		rating := store.OMDbRating{
+		metadata := store.MovieMetadata{
+			Provider:   data.Provider,
 			ImdbRating: data.ImdbRating,
 			Metascore:  data.Metascore,
-			Rotten:     rotten,
+			Rotten:     data.Rotten,
+			TMDbRating: data.TMDbRating,
 			Genre:      data.Genre,
 			Director:   data.Director,
 			Plot:       data.Plot,
+			Cast:       data.Cast,
+			Trailer:    data.Trailer,
+			Poster:     data.Poster,
 		}
 
 		// Salva no cache
-		_ = store.SaveMovieRating(title, rating)
+		_ = store.SaveMovieMetadata(name, title, metadata)
 
-		return omdbRatingMsg{title: title, rating: rating}
+		return movieMetadataMsg{gen: m.requestGen, title: title, metadata: metadata}
 	}
 }
 
@@ -325,7 +498,7 @@ func (m appModel) View() string {
 	content := ""
 
 	switch m.state {
-	case stateLoadingCities, stateLoadingTheaters, stateLoadingSessions, stateLoadingSeatMap:
+	case stateLoadingCities, stateLoadingTheaters, stateLoadingSessions, stateLoadingSeatMap, stateLoadingWeek:
 		content = m.loadingView()
 	case stateSelectCity:
 		content = m.cityList.View()
@@ -337,10 +510,14 @@ func (m appModel) View() string {
 		content = m.renderSplitView(m.movieList.View())
 	case stateShowSessions:
 		content = m.renderSplitView(m.sessionList.View())
+	case stateShowMovie:
+		content = m.renderMoviePage()
+	case stateShowWeek:
+		content = m.renderWeek()
 	case stateSelectSection:
 		content = m.sectionList.View()
 	case stateShowSeatMap:
-		content = m.renderSeatMap()
+		content = m.renderSeatMap() + "\n" + m.seatWatchStatus()
 	case stateSelectDate:
 		content = m.dateList.View()
 	case stateError:
@@ -351,6 +528,10 @@ func (m appModel) View() string {
 		}
 	}
 
+	if m.showRequests {
+		content = m.renderRequestLog()
+	}
+
 	return header + "\n" + content
 }
 
@@ -369,7 +550,11 @@ func (m appModel) renderSplitView(left string) string {
 		BorderForeground(lipgloss.Color("240")).
 		PaddingLeft(2)
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, leftStyle.Render(left), rightStyle.Render(m.renderMovieDetail(rightWidth-2)))
+	detail := m.renderMovieDetail(rightWidth - 2)
+	if m.state == stateShowSessions {
+		detail = m.renderSessionPrices(rightWidth-2) + detail
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, leftStyle.Render(left), rightStyle.Render(detail))
 }
 
 func (m appModel) renderMovieDetail(maxWidth int) string {
@@ -385,6 +570,14 @@ func (m appModel) renderMovieDetail(maxWidth int) string {
 
 	movie := movieItem.movie
 
+	// O pôster ocupa a esquerda do painel quando sobra espaço para os detalhes
+	poster, hasPoster := m.posters[movie.Title]
+	if hasPoster && maxWidth >= posterCols+2+minDetailWidth {
+		maxWidth -= posterCols + 2
+	} else {
+		hasPoster = false
+	}
+
 	// Styles
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63")).MarginBottom(1)
 	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Width(15)
@@ -447,14 +640,14 @@ func (m appModel) renderMovieDetail(maxWidth int) string {
 	if len(movieItem.globalSessions) > 0 {
 		theaters := make(map[string]bool)
 		for _, s := range movieItem.globalSessions {
-			theaters[s.theater.Name] = true
+			theaters[s.Theater.Name] = true
 		}
 		content += lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Cinemas:"), valueStyle.Render(fmt.Sprintf("🍿 Disponível em %d locais", len(theaters)))) + "\n\n"
 	}
 
-	if rating, ok := m.movieRatings[movie.Title]; ok {
+	if rating, ok := m.movieMetadata[movie.Title]; ok {
 		if rating.NotFound {
-			content += lipgloss.NewStyle().Faint(true).Italic(true).Render("Filme não encontrado no IMDb.") + "\n\n"
+			content += lipgloss.NewStyle().Faint(true).Italic(true).Render("Filme não encontrado nas bases de filmes.") + "\n\n"
 		} else {
 			var ratings []string
 			if rating.ImdbRating != "" && rating.ImdbRating != "N/A" {
@@ -463,6 +656,9 @@ func (m appModel) renderMovieDetail(maxWidth int) string {
 			if rating.Rotten != "" && rating.Rotten != "N/A" {
 				ratings = append(ratings, "🍅 "+rating.Rotten)
 			}
+			if rating.TMDbRating != "" {
+				ratings = append(ratings, "🎬 "+rating.TMDbRating+" TMDb")
+			}
 
 			if len(ratings) > 0 {
 				content += lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Notas:"), valueStyle.Render(strings.Join(ratings, "   "))) + "\n\n"
@@ -474,6 +670,16 @@ func (m appModel) renderMovieDetail(maxWidth int) string {
 			if rating.Director != "" && rating.Director != "N/A" {
 				content += lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Diretor:"), valueStyle.Render(rating.Director)) + "\n\n"
 			}
+			if len(rating.Cast) > 0 {
+				cast := rating.Cast
+				if len(cast) > detailCastSize {
+					cast = cast[:detailCastSize]
+				}
+				content += lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Elenco:"), valueStyle.Render(strings.Join(cast, ", "))) + "\n\n"
+			}
+			if rating.Trailer != "" {
+				content += lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Trailer:"), valueStyle.Render(rating.Trailer)) + "\n\n"
+			}
 
 			if rating.Plot != "" && rating.Plot != "N/A" {
 				plotStyle := lipgloss.NewStyle().
@@ -483,10 +689,13 @@ func (m appModel) renderMovieDetail(maxWidth int) string {
 				content += plotStyle.Render(rating.Plot) + "\n\n"
 			}
 		}
-	} else if os.Getenv("OMDB_API_KEY") == "" {
-		content += lipgloss.NewStyle().Faint(true).Italic(true).Render("Dica: Defina a env OMDB_API_KEY para ver notas e detalhes.") + "\n\n"
+	} else if m.metadata == nil || !m.metadata.Enabled() {
+		content += lipgloss.NewStyle().Faint(true).Italic(true).Render("Dica: Defina a env TMDB_API_KEY ou OMDB_API_KEY para ver notas e detalhes.") + "\n\n"
 	}
 
+	if hasPoster {
+		content = lipgloss.JoinHorizontal(lipgloss.Top, poster, "  ", content)
+	}
 	return content
 }
 
@@ -514,7 +723,7 @@ func (m appModel) headerView() string {
 	if m.theater.Name != "" {
 		bc = append(bc, m.theater.Name)
 	}
-	if m.state == stateShowSessions || m.state == stateShowSeatMap {
+	if m.state == stateShowSessions || m.state == stateShowSeatMap || m.state == stateShowMovie || m.state == stateShowWeek {
 		if m.movieList.SelectedItem() != nil {
 			if movie, ok := m.movieList.SelectedItem().(movieItem); ok {
 				bc = append(bc, movie.movie.Title)
@@ -550,6 +759,9 @@ func (m appModel) headerView() string {
 	if m.browsingAllTheaters {
 		meta = append(meta, "🌐 Todos os Cinemas")
 	}
+	if !m.staleSince.IsZero() {
+		meta = append(meta, offlineLabel(m.staleSince, m.now()))
+	}
 
 	rightSide := ""
 	if len(meta) > 0 {
@@ -571,16 +783,36 @@ func (m appModel) headerView() string {
 	}
 
 	// Dynamic Help/Hints
-	hints := []string{"q sair", "esc voltar", "ctrl+d data"}
+	hints := []string{"q sair", "esc voltar", "ctrl+d data", "ctrl+g requisições"}
 	switch m.state {
 	case stateSelectTheater:
 		hints = append(hints, "enter selecionar", "ctrl+f buscar filme", "ctrl+t gerenciar", "ctrl+l localizar")
+	case stateSelectMovie:
+		hints = append(hints, "enter sessões", "ctrl+o ficha do filme", "ctrl+w semana")
+	case stateShowMovie:
+		hints = append(hints, "enter sessões", "t trailer")
 	case stateShowSessions:
-		hints = append(hints, "enter checkout", "tab assentos")
+		hints = append(hints, "enter checkout", "tab assentos", "ctrl+e agenda", "ctrl+p combinação", "ctrl+w semana")
+	case stateShowWeek:
+		hints = append(hints, "↑/↓ dia", "enter abrir o dia")
+	case stateSelectDate:
+		if m.pickingMovieRange() {
+			if m.rangeStart.IsZero() {
+				hints = append(hints, "tab início do período")
+			} else {
+				hints = append(hints, "enter fim do período", "tab desmarcar início")
+			}
+		}
 	case stateManageTheaters:
 		hints = append(hints, "enter/x alternar")
 	case stateShowSeatMap:
 		hints = append(hints, "n números")
+		if m.seatWatching {
+			hints = append(hints, "w parar vigia")
+		} else {
+			hints = append(hints, "w vigiar")
+		}
+		hints = append(hints, "+/- tamanho do bloco", "s assentos")
 	}
 
 	// Filter status
@@ -596,7 +828,21 @@ func (m appModel) headerView() string {
 
 	helpLine := "\n" + hint(strings.Join(hints, " • "))
 
-	return "\n" + headerLine + filterLine + helpLine + "\n"
+	noticeLine := ""
+	if m.editingMix {
+		noticeLine = "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("63")).Render("🎟️  Combinação: "+m.mixInput+"▏") + "\n" + hint("enter aplica • esc cancela • ex.: 2 inteira + 1 meia")
+	}
+	if m.editingSeatLabels {
+		noticeLine = "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("63")).Render("🪑 Assentos: "+m.seatLabelInput+"▏") + "\n" + hint("enter aplica • esc cancela • vazio volta ao bloco • ex.: G12, G13")
+	}
+	if m.notice != "" {
+		noticeLine += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render(m.notice)
+	}
+	if m.seatAlert != "" {
+		noticeLine += "\n" + lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")).Render(m.seatAlert) + "  " + hint("ctrl+x dispensa")
+	}
+
+	return "\n" + headerLine + filterLine + helpLine + noticeLine + "\n"
 }
 
 func (m appModel) errorRecoveryView() string {
@@ -673,6 +919,8 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 	switch msg.String() {
 	case "ctrl+c", "q":
 		return m, tea.Quit, true
+	case "ctrl+g":
+		return m.toggleRequestLog()
 	case "esc":
 		if listPtr := m.activeList(); listPtr != nil {
 			if listPtr.SettingFilter() {
@@ -690,10 +938,46 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 			m.showSeatNumbers = !m.showSeatNumbers
 			return m, nil, true
 		}
+	case "w":
+		if m.state == stateShowSeatMap {
+			return m.toggleSeatWatch()
+		}
+	case "+", "-":
+		if m.state == stateShowSeatMap {
+			step := 1
+			if msg.String() == "-" {
+				step = -1
+			}
+			m.seatWatchSize = min(maxSeatWatchSize, max(1, m.seatWatchSize+step))
+			m.seatWatchLabels = nil
+			return m, nil, true
+		}
+	case "s":
+		if m.state == stateShowSeatMap {
+			m.editingSeatLabels = true
+			m.seatLabelInput = strings.Join(m.seatWatchLabels, ", ")
+			return m, nil, true
+		}
 	case "tab":
 		if m.state == stateShowSessions {
 			return m.openSeatMapFromSelection()
 		}
+		if m.state == stateSelectDate && m.pickingMovieRange() {
+			return m.toggleRangeStart()
+		}
+	case "ctrl+w":
+		if m.state == stateSelectMovie || m.state == stateShowSessions {
+			from := truncateDate(m.date)
+			return m.openWeek(from, from.AddDate(0, 0, weekDays-1))
+		}
+	case "up", "k", "down", "j":
+		if m.state == stateShowWeek {
+			step := 1
+			if msg.String() == "up" || msg.String() == "k" {
+				step = -1
+			}
+			return m.moveWeekCursor(step)
+		}
 	case "ctrl+l":
 		if m.state == stateSelectTheater || m.state == stateManageTheaters {
 			return m, m.detectLocationCmd(), true
@@ -706,6 +990,25 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		if m.state == stateSelectTheater {
 			return m.openMovieAcrossTheaters()
 		}
+	case "ctrl+e":
+		if m.state == stateShowSessions {
+			return m.exportSelectedSession()
+		}
+	case "ctrl+p":
+		if m.state == stateShowSessions {
+			return m.startTicketMixEdit()
+		}
+	case "ctrl+o":
+		if m.state == stateSelectMovie {
+			return m.openMoviePage()
+		}
+	case "t":
+		if m.state == stateShowMovie && m.movieEvent != nil {
+			if url := eventTrailerURL(*m.movieEvent); url != "" {
+				return m, openURLCmd(url), true
+			}
+			return m, nil, true
+		}
 	}
 
 	if msg.String() == "ctrl+t" && m.state == stateSelectTheater {
@@ -715,12 +1018,10 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 	}
 
 	if msg.String() == "ctrl+d" && (m.state == stateSelectCity || m.state == stateSelectTheater || m.state == stateSelectMovie || m.state == stateShowSessions) {
-		m.openDatePicker(m.state)
-		return m, nil, true
+		return m, m.openDatePicker(m.state), true
 	}
 	if msg.String() == "ctrl+d" && m.state == stateError && m.errorSuggestNextDay {
-		m.openDatePicker(stateShowSessions)
-		return m, nil, true
+		return m, m.openDatePicker(stateShowSessions), true
 	}
 
 	if msg.Type == tea.KeyEnter {
@@ -729,12 +1030,17 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		}
 		switch m.state {
 		case stateSelectCity:
+			if group, ok := m.cityList.SelectedItem().(cityGroupItem); ok {
+				return m.openCityGroup(group.group)
+			}
 			item, ok := m.cityList.SelectedItem().(cityItem)
 			if !ok {
 				return m, nil, true
 			}
 			m.city = item.city
+			m.cityGroup = store.CityGroup{}
 			_ = store.RememberCity(m.city)
+			m.beginRequests()
 			m.state = stateLoadingTheaters
 			return m, tea.Batch(m.fetchTheatersCmd(m.city.Id), m.spinner.Tick), true
 		case stateSelectTheater:
@@ -744,10 +1050,11 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 			}
 			m.theater = item.theater
 			m.browsingAllTheaters = false
-			_ = store.RememberTheater(m.city.Id, m.theater)
+			_ = store.RememberTheater(m.theaterCityID(m.theater), m.theater)
+			m.beginRequests()
 			m.state = stateLoadingSessions
-			return m, tea.Batch(m.fetchSessionsCmd(m.city.Id, m.theater.Id, m.date), m.spinner.Tick), true
-		case stateSelectMovie:
+			return m, tea.Batch(m.fetchSessionsCmd(m.theaterCityID(m.theater), m.theater.Id, m.date), m.spinner.Tick), true
+		case stateSelectMovie, stateShowMovie:
 			item, ok := m.movieList.SelectedItem().(movieItem)
 			if !ok {
 				return m, nil, true
@@ -759,7 +1066,13 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 			} else {
 				items, _ = buildSessionItems(item.movie, m.seatCounts)
 			}
+			for i, item := range items {
+				si := item.(sessionItem)
+				si.prices = m.sessionPrices[si.session.Id]
+				items[i] = si
+			}
 			m.sessionList.SetItems(items)
+			m.beginRequests()
 			m.state = stateShowSessions
 			if cmd := m.startSeatCountFetchForVisiblePage(); cmd != nil {
 				return m, cmd, true
@@ -767,19 +1080,21 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 			return m, nil, true
 		case stateManageTheaters:
 			return m.toggleTheaterVisibility()
+		case stateShowWeek:
+			return m.openWeekDay()
 		case stateShowSessions:
 			item, ok := m.sessionList.SelectedItem().(sessionItem)
 			if !ok {
 				return m, nil, true
 			}
-			url := fmt.Sprintf("https://checkout.ingresso.com/assentos?sessionId=%s&partnership=home", item.session.Id)
-			return m, openURLCmd(url), true
+			return m, openURLCmd(service.CheckoutURL(item.session.Id)), true
 		case stateSelectSection:
 			item, ok := m.sectionList.SelectedItem().(sectionItem)
 			if !ok {
 				return m, nil, true
 			}
 			m.selectedSection = item.section
+			m.beginRequests()
 			m.state = stateLoadingSeatMap
 			return m, tea.Batch(m.fetchSeatMapCmd(m.selectedSession.Id, m.selectedSection.Id), m.spinner.Tick), true
 		case stateSelectDate:
@@ -787,7 +1102,11 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 			if !ok {
 				return m, nil, true
 			}
+			if !m.rangeStart.IsZero() && m.pickingMovieRange() {
+				return m.selectDateRange(item.date)
+			}
 			m.date = item.date
+			m.beginRequests()
 			if m.dateReturnStateSet {
 				switch m.dateReturnState {
 				case stateSelectCity, stateSelectTheater:
@@ -808,7 +1127,7 @@ func (m appModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 			}
 			m.state = stateLoadingSessions
 			m.dateReturnStateSet = false
-			return m, tea.Batch(m.fetchSessionsCmd(m.city.Id, m.theater.Id, m.date), m.spinner.Tick), true
+			return m, tea.Batch(m.fetchSessionsCmd(m.theaterCityID(m.theater), m.theater.Id, m.date), m.spinner.Tick), true
 		}
 	}
 	return m, nil, false
@@ -823,21 +1142,93 @@ func (m appModel) openSeatMapFromSelection() (tea.Model, tea.Cmd, bool) {
 		return m, errCmd(errors.New("this session does not support seat selection")), true
 	}
 	m.selectedSession = item.session
+	m.beginRequests()
 	m.state = stateLoadingSeatMap
 	return m, tea.Batch(m.fetchSessionDetailsCmd(item.session.Id), m.spinner.Tick), true
 }
 
+func (m appModel) exportSelectedSession() (tea.Model, tea.Cmd, bool) {
+	item, ok := m.sessionList.SelectedItem().(sessionItem)
+	if !ok {
+		return m, nil, true
+	}
+	movie, ok := m.movieList.SelectedItem().(movieItem)
+	if !ok {
+		return m, nil, true
+	}
+	event := finder.SessionEvent(movie.movie, item.session, m.sessionTheater(movie, item.session.Id))
+	return m, writeEventCmd(event, fmt.Sprintf("ingresso-%s.ics", item.session.Id)), true
+}
+
+// sessionTheater returns the theater showing sessionID, which in global mode
+// differs per session and otherwise is the selected theater.
+func (m appModel) sessionTheater(movie movieItem, sessionID string) model.Theater {
+	for _, entry := range movie.globalSessions {
+		if entry.Session.Id == sessionID {
+			return entry.Theater
+		}
+	}
+	return m.theater
+}
+
+func writeEventCmd(event ics.Event, name string) tea.Cmd {
+	return func() tea.Msg {
+		path := filepath.Join(exportDir(), name)
+		file, err := os.Create(path)
+		if err != nil {
+			return errMsg{err: fmt.Errorf("export event: %w", err)}
+		}
+		if err := ics.Write(file, []ics.Event{event}); err != nil {
+			_ = file.Close()
+			return errMsg{err: fmt.Errorf("export event: %w", err)}
+		}
+		if err := file.Close(); err != nil {
+			return errMsg{err: fmt.Errorf("export event: %w", err)}
+		}
+		return noticeMsg{text: "📅 Evento salvo em " + path}
+	}
+}
+
+// exportDir prefers the user's Downloads folder and falls back to the working directory.
+func exportDir() string {
+	if home, err := os.UserHomeDir(); err == nil {
+		downloads := filepath.Join(home, "Downloads")
+		if info, err := os.Stat(downloads); err == nil && info.IsDir() {
+			return downloads
+		}
+	}
+	if wd, err := os.Getwd(); err == nil {
+		return wd
+	}
+	return "."
+}
+
+// goBack returns to the previous screen, canceling the requests of the one
+// being left.
 func (m appModel) goBack() (tea.Model, tea.Cmd) {
 	switch m.state {
+	case stateLoadingCities:
+		// There is nothing to go back to before the cities load.
+		return m, nil
+	case stateLoadingTheaters, stateLoadingSessions, stateLoadingSeatMap, stateLoadingWeek:
+		// Leaving a screen that is still loading cancels its requests, like
+		// the cross-theater crawl.
+		m.state = recoverStateFrom(m.state)
+		if m.state == stateSelectCity && len(m.cityList.Items()) == 0 {
+			m.beginRequests()
+			m.state = stateLoadingCities
+			return m, tea.Batch(m.fetchCitiesCmd(), m.spinner.Tick)
+		}
 	case stateSelectTheater:
 		if len(m.cityList.Items()) == 0 {
+			m.beginRequests()
 			m.state = stateLoadingCities
 			return m, tea.Batch(m.fetchCitiesCmd(), m.spinner.Tick)
 		}
 		m.state = stateSelectCity
 	case stateSelectMovie:
 		m.state = stateSelectTheater
-	case stateShowSessions:
+	case stateShowSessions, stateShowMovie, stateShowWeek:
 		m.state = stateSelectMovie
 	case stateManageTheaters:
 		m.state = stateSelectTheater
@@ -845,6 +1236,7 @@ func (m appModel) goBack() (tea.Model, tea.Cmd) {
 		m.state = stateShowSessions
 	case stateShowSeatMap:
 		m.state = stateShowSessions
+		m.seatWatching = false
 	case stateSelectDate:
 		if m.dateReturnStateSet {
 			m.state = m.dateReturnState
@@ -858,6 +1250,11 @@ func (m appModel) goBack() (tea.Model, tea.Cmd) {
 	default:
 		return m, nil
 	}
+	m.beginRequests()
+	if m.state == stateShowSessions {
+		// Seat counts canceled on the way out are requested again.
+		return m, m.startSeatCountFetchForVisiblePage()
+	}
 	return m, nil
 }
 
@@ -911,11 +1308,32 @@ func (m *appModel) popFilter(listPtr *list.Model) {
 	listPtr.SetFilterText(value)
 }
 
-func (m *appModel) openDatePicker(returnState appState) {
+// openDatePicker offers the days from m.date on and looks up, in the
+// background, which of them have sessions: those of the selected theater, or
+// across theaters those of the highlighted movie, from the schedules its week
+// view reads. Across theaters without a movie every day is offered, since
+// Ingresso has no city-wide list of days.
+func (m *appModel) openDatePicker(returnState appState) tea.Cmd {
 	m.dateReturnState = returnState
 	m.dateReturnStateSet = true
 	m.state = stateSelectDate
-	m.dateList.SetItems(buildDateItems(m.date))
+	m.availableDays = nil
+	m.rangeStart = time.Time{}
+	m.dateList.Title = "Select Date"
+	m.dateList.SetItems(buildDateItems(m.date, nil, time.Time{}))
+	if !m.browsingAllTheaters {
+		if m.theater.Id == "" {
+			return nil
+		}
+		return m.fetchAvailableDaysCmd(m.theater)
+	}
+	item, ok := m.movieList.SelectedItem().(movieItem)
+	theaters := m.visibleTheaters()
+	if !ok || !m.pickingMovieRange() || len(theaters) == 0 {
+		return nil
+	}
+	m.dateList.Title = "Select Date • days with " + item.movie.Title
+	return m.fetchMovieDaysCmd(theaters, item.movie)
 }
 
 func trimLastRune(value string) string {
@@ -949,7 +1367,8 @@ func (m appModel) isLoadingState() bool {
 	return m.state == stateLoadingCities ||
 		m.state == stateLoadingTheaters ||
 		m.state == stateLoadingSessions ||
-		m.state == stateLoadingSeatMap
+		m.state == stateLoadingSeatMap ||
+		m.state == stateLoadingWeek
 }
 
 func (m appModel) loadingView() string {
@@ -963,6 +1382,8 @@ func (m appModel) loadingView() string {
 		title = "Loading sessions"
 	case stateLoadingSeatMap:
 		title = "Loading seat map"
+	case stateLoadingWeek:
+		title = "Loading week"
 	}
 
 	return fmt.Sprintf("%s %s\n\n%s", m.spinner.View(), title, hint("Fetching data..."))
@@ -1031,6 +1452,8 @@ func recoverStateFrom(state appState) appState {
 		return stateSelectTheater
 	case stateLoadingSeatMap:
 		return stateShowSessions
+	case stateLoadingWeek:
+		return stateSelectMovie
 	case stateError:
 		return stateSelectTheater
 	default:
@@ -1075,29 +1498,29 @@ func openURL(url string) error {
 
 func (m appModel) fetchCityByNameCmd(name string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		city, err := m.client.GetCityInfoByName(ctx, name)
+		ctx := m.requestContext()
+		city, err := m.finder.CityByName(ctx, name)
 		if err != nil {
-			return cityMsg{err: err}
+			return cityMsg{gen: m.requestGen, err: err}
 		}
-		return cityMsg{city: city, err: nil}
+		return cityMsg{gen: m.requestGen, city: city, err: nil}
 	}
 }
 
 func (m appModel) fetchRecentCityCmd(recent store.RecentCity) tea.Cmd {
 	return func() tea.Msg {
 		if city, ok := cityFromRecentCache(recent); ok {
-			return cityMsg{city: city, err: nil}
+			return cityMsg{gen: m.requestGen, city: city, err: nil}
 		}
 		if strings.TrimSpace(recent.Name) == "" {
-			return cityMsg{err: errors.New("recent city not found")}
+			return cityMsg{gen: m.requestGen, err: errors.New("recent city not found")}
 		}
-		ctx := context.Background()
-		city, err := m.client.GetCityInfoByName(ctx, recent.Name)
+		ctx := m.requestContext()
+		city, err := m.finder.CityByName(ctx, recent.Name)
 		if err != nil {
-			return cityMsg{err: err}
+			return cityMsg{gen: m.requestGen, err: err}
 		}
-		return cityMsg{city: city, err: nil}
+		return cityMsg{gen: m.requestGen, city: city, err: nil}
 	}
 }
 
@@ -1134,140 +1557,276 @@ func cityFromRecentCache(recent store.RecentCity) (model.City, bool) {
 	return model.City{}, false
 }
 
-func (m appModel) fetchCitiesCmd() tea.Cmd {
-	return func() tea.Msg {
-		if cached, fresh, err := store.LoadCityCache(); err == nil && fresh && len(cached) > 0 {
-			return citiesMsg{cities: cached}
+// requestContext is the context of the current screen's requests.
+func (m appModel) requestContext() context.Context {
+	if m.requestCtx == nil {
+		return context.Background()
+	}
+	return m.requestCtx
+}
+
+// beginRequests cancels the requests of the screen being left and starts a
+// new generation for the next one. Seat counts and prices that were still
+// loading are forgotten so they are requested again when their page is shown.
+func (m *appModel) beginRequests() {
+	if m.cancelRequests != nil {
+		m.cancelRequests()
+	}
+	m.requestCtx, m.cancelRequests = context.WithCancel(context.Background())
+	m.requestGen++
+	for id, count := range m.seatCounts {
+		if !count.loaded {
+			delete(m.seatCounts, id)
 		}
-		ctx := context.Background()
-		cities, err := m.client.GetCities(ctx)
-		if err == nil && len(cities) > 0 {
-			_ = store.SaveCityCache(cities)
+	}
+	for id, prices := range m.sessionPrices {
+		if !prices.loaded {
+			delete(m.sessionPrices, id)
 		}
-		return citiesMsg{cities: cities, err: err}
+	}
+}
+
+// requestGenOf returns the generation of messages answering screen requests.
+func requestGenOf(msg tea.Msg) (int, bool) {
+	switch msg := msg.(type) {
+	case cityMsg:
+		return msg.gen, true
+	case citiesMsg:
+		return msg.gen, true
+	case theatersMsg:
+		return msg.gen, true
+	case sessionsMsg:
+		return msg.gen, true
+	case movieCatalogMsg:
+		return msg.gen, true
+	case sessionDetailsMsg:
+		return msg.gen, true
+	case seatMapMsg:
+		return msg.gen, true
+	case seatCountMsg:
+		return msg.gen, true
+	case sessionPricesMsg:
+		return msg.gen, true
+	case eventMsg:
+		return msg.gen, true
+	case weekMsg:
+		return msg.gen, true
+	case availableDaysMsg:
+		return msg.gen, true
+	case movieMetadataMsg:
+		return msg.gen, true
+	case locationMsg:
+		return msg.gen, true
+	}
+	return 0, false
+}
+
+// staleSinceOf returns when the stale cache data behind a load message was
+// saved, zero when it was all fresh.
+func staleSinceOf(msg tea.Msg) (time.Time, bool) {
+	switch msg := msg.(type) {
+	case citiesMsg:
+		return msg.staleSince, true
+	case theatersMsg:
+		return msg.staleSince, true
+	case sessionsMsg:
+		return msg.staleSince, true
+	case movieCatalogMsg:
+		return msg.staleSince, true
+	case weekMsg:
+		return msg.staleSince, true
+	}
+	return time.Time{}, false
+}
+
+// offlineLabel tells when the stale data on screen was saved, adding the day
+// when it is not today.
+func offlineLabel(since time.Time, now time.Time) string {
+	if isSameDay(since, now) {
+		return "📴 offline, dados das " + since.Format("15:04")
+	}
+	return "📴 offline, dados de " + since.Format("02/01 15:04")
+}
+
+func (m appModel) fetchCitiesCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx, stale := finder.TrackStale(m.requestContext())
+		cities, err := m.finder.Cities(ctx)
+		return citiesMsg{gen: m.requestGen, cities: cities, staleSince: stale.Since(), err: err}
 	}
 }
 
 func (m appModel) fetchTheatersCmd(cityID string) tea.Cmd {
 	return func() tea.Msg {
-		if cached, fresh, err := store.LoadTheaterCache(cityID); err == nil && fresh && len(cached) > 0 {
-			return theatersMsg{theaters: cached}
-		}
-		ctx := context.Background()
-		theaters, err := m.client.GetTheatersByCity(ctx, cityID)
-		if err == nil && len(theaters) > 0 {
-			_ = store.SaveTheaterCache(cityID, theaters)
-		}
-		return theatersMsg{theaters: theaters, err: err}
+		ctx, stale := finder.TrackStale(m.requestContext())
+		theaters, err := m.finder.Theaters(ctx, cityID)
+		return theatersMsg{gen: m.requestGen, theaters: theaters, staleSince: stale.Since(), err: err}
 	}
 }
 
 func (m appModel) fetchSessionsCmd(cityID string, theaterID string, date time.Time) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		days, err := m.loadSessionsByTheater(ctx, cityID, theaterID, date)
+		ctx, stale := finder.TrackStale(m.requestContext())
+		days, err := m.finder.Sessions(ctx, cityID, theaterID, date)
 		if err != nil {
 			if service.IsNotFound(err) {
-				return sessionsMsg{days: nil, err: nil}
+				return sessionsMsg{gen: m.requestGen, days: nil, err: nil}
 			}
-			return sessionsMsg{days: nil, err: err}
+			return sessionsMsg{gen: m.requestGen, days: nil, err: err}
 		}
-		return sessionsMsg{days: days, err: err}
-	}
-}
-
-func (m appModel) loadSessionsByTheater(ctx context.Context, cityID string, theaterID string, date time.Time) ([]model.TheaterSessionDay, error) {
-	dateKey := date.Format(time.DateOnly)
-	if cached, fresh, err := store.LoadSessionCache(cityID, theaterID, dateKey); err == nil && fresh && len(cached) > 0 {
-		return cached, nil
-	}
-	days, err := m.client.GetSessionsByCityAndTheater(ctx, cityID, theaterID, &date)
-	if err != nil {
-		return nil, err
+		return sessionsMsg{gen: m.requestGen, days: days, staleSince: stale.Since(), err: err}
 	}
-	if len(days) > 0 {
-		_ = store.SaveSessionCache(cityID, theaterID, dateKey, days)
-	}
-	return days, nil
 }
 
 func (m appModel) fetchMovieCatalogCmd(cityID string, theaters []model.Theater, date time.Time) tea.Cmd {
 	return func() tea.Msg {
-		if len(theaters) == 0 {
-			return movieCatalogMsg{err: errors.New("no theaters available")}
-		}
-
-		ctx := context.Background()
-		out := make(chan theaterSessionsResult, len(theaters))
-		sem := make(chan struct{}, 6)
-		var wg sync.WaitGroup
-
-		for _, theater := range theaters {
-			wg.Add(1)
-			go func(theater model.Theater) {
-				defer wg.Done()
-				sem <- struct{}{}
-				days, err := m.loadSessionsByTheater(ctx, cityID, theater.Id, date)
-				<-sem
-				out <- theaterSessionsResult{theater: theater, days: days, err: err}
-			}(theater)
+		ctx, stale := finder.TrackStale(m.requestContext())
+		catalog, err := m.finder.Catalog(ctx, cityID, theaters, date, m.userLocation)
+		if err != nil {
+			return movieCatalogMsg{gen: m.requestGen, err: err}
 		}
-
-		wg.Wait()
-		close(out)
-
-		movies, failed, ignored := aggregateMovieCatalog(out, date, m.userLocation)
-		if len(movies) == 0 {
+		if len(catalog.Movies) == 0 {
 			return movieCatalogMsg{
+				gen:        m.requestGen,
 				err:        fmt.Errorf("no sessions found in visible theaters on %s", date.Format(time.DateOnly)),
-				failed:     failed,
-				ignored:    ignored,
+				failed:     catalog.Failed,
+				ignored:    catalog.Ignored,
 				noSessions: true,
+				staleSince: stale.Since(),
 			}
 		}
-		return movieCatalogMsg{movies: movies, failed: failed, ignored: ignored}
+		return movieCatalogMsg{gen: m.requestGen, movies: catalog.Movies, failed: catalog.Failed, ignored: catalog.Ignored, staleSince: stale.Since()}
 	}
 }
 
 func (m appModel) detectLocationCmd() tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := m.requestContext()
 		location, err := service.DetectCurrentLocation(ctx, nil)
 		if err != nil {
-			return locationMsg{err: fmt.Errorf("failed to detect current location: %w", err)}
+			return locationMsg{gen: m.requestGen, err: fmt.Errorf("failed to detect current location: %w", err)}
 		}
-		return locationMsg{location: location}
+		return locationMsg{gen: m.requestGen, location: location}
 	}
 }
 
 func (m appModel) fetchSessionDetailsCmd(sessionID string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := m.requestContext()
 		detail, err := m.client.GetSessionDetails(ctx, sessionID)
-		return sessionDetailsMsg{detail: detail, err: err}
+		return sessionDetailsMsg{gen: m.requestGen, detail: detail, err: err}
 	}
 }
 
 func (m appModel) fetchSeatMapCmd(sessionID string, sectionID string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := m.requestContext()
+		seatMap, err := m.client.GetSeatMap(ctx, sessionID, sectionID)
+		return seatMapMsg{gen: m.requestGen, seatMap: seatMap, err: err}
+	}
+}
+
+func (m appModel) fetchSeatWatchCmd(gen int, sessionID string, sectionID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := service.WithoutResponseCache(m.requestContext())
 		seatMap, err := m.client.GetSeatMap(ctx, sessionID, sectionID)
-		return seatMapMsg{seatMap: seatMap, err: err}
+		return seatWatchMsg{gen: gen, seatMap: seatMap, err: err}
+	}
+}
+
+func seatWatchTickCmd(gen int) tea.Cmd {
+	return tea.Tick(seatWatchInterval, func(time.Time) tea.Msg {
+		return seatWatchTickMsg{gen: gen}
+	})
+}
+
+// toggleSeatWatch starts or stops polling the open seat map. The first check
+// runs right away. Every start gets a new generation so ticks from an earlier
+// watch are ignored.
+func (m appModel) toggleSeatWatch() (tea.Model, tea.Cmd, bool) {
+	if m.seatWatching {
+		m.seatWatching = false
+		return m, nil, true
 	}
+	m.seatWatching = true
+	m.seatWatchGen++
+	m.seatWatchChecked = time.Now()
+	m.seatAlert = ""
+	return m, m.fetchSeatWatchCmd(m.seatWatchGen, m.selectedSession.Id, m.selectedSection.Id), true
+}
+
+// seatWatch is what the watch waits for: the chosen seats, or else a block of
+// seatWatchSize adjacent seats.
+func (m appModel) seatWatch() finder.SeatWatch {
+	if len(m.seatWatchLabels) > 0 {
+		return finder.SeatWatch{Labels: m.seatWatchLabels}
+	}
+	return finder.SeatWatch{Adjacent: m.seatWatchSize}
+}
+
+// handleSeatLabelInput edits the seat prompt. Enter applies the comma
+// separated seats, and an empty prompt goes back to watching a block.
+func (m *appModel) handleSeatLabelInput(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		var labels []string
+		for _, label := range strings.Split(m.seatLabelInput, ",") {
+			if label = finder.NormalizeSeatLabel(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+		m.seatWatchLabels = labels
+		m.editingSeatLabels = false
+	case tea.KeyEsc:
+		m.editingSeatLabels = false
+	case tea.KeyBackspace:
+		if runes := []rune(m.seatLabelInput); len(runes) > 0 {
+			m.seatLabelInput = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.seatLabelInput += " "
+	case tea.KeyRunes:
+		m.seatLabelInput += string(msg.Runes)
+	}
+}
+
+func (m appModel) seatWatchStatus() string {
+	target := fmt.Sprintf("bloco de %d lugares", m.seatWatchSize)
+	if len(m.seatWatchLabels) > 0 {
+		target = "assentos " + strings.Join(m.seatWatchLabels, ", ")
+	}
+	if !m.seatWatching {
+		return hint("Vigia desligada • " + target)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(fmt.Sprintf(
+		"👀 Vigiando %s • atualizado às %s • a cada %s",
+		target, m.seatWatchChecked.Format("15:04:05"), seatWatchInterval,
+	))
 }
 
 type dateItem struct {
 	date time.Time
+	// empty marks a day known to have no sessions; rangeStart the first day
+	// of a range being picked.
+	empty      bool
+	rangeStart bool
 }
 
 func (d dateItem) Title() string {
+	title := fmt.Sprintf("%s • %s", d.date.Format("Mon"), d.date.Format("02/01"))
 	if isSameDay(d.date, time.Now()) {
-		return fmt.Sprintf("%s • %s (Today)", d.date.Format("Mon"), d.date.Format("02/01"))
+		title += " (Today)"
 	}
-	return fmt.Sprintf("%s • %s", d.date.Format("Mon"), d.date.Format("02/01"))
+	if d.rangeStart {
+		title = "▶ " + title
+	}
+	return title
 }
 
 func (d dateItem) Description() string {
+	if d.empty {
+		return d.date.Format(time.DateOnly) + " • no sessions"
+	}
 	return d.date.Format(time.DateOnly)
 }
 
@@ -1275,11 +1834,18 @@ func (d dateItem) FilterValue() string {
 	return d.Title()
 }
 
-func buildDateItems(base time.Time) []list.Item {
+// buildDateItems lists the days from base on. available, when known, holds
+// the days with sessions; the others are marked empty.
+func buildDateItems(base time.Time, available map[string]bool, rangeStart time.Time) []list.Item {
 	start := truncateDate(base)
-	items := make([]list.Item, 0, 5)
-	for i := range 5 {
-		items = append(items, dateItem{date: start.AddDate(0, 0, i)})
+	items := make([]list.Item, 0, datePickerDays)
+	for i := range datePickerDays {
+		date := start.AddDate(0, 0, i)
+		items = append(items, dateItem{
+			date:       date,
+			empty:      available != nil && !available[date.Format(time.DateOnly)],
+			rangeStart: isSameDay(date, rangeStart),
+		})
 	}
 	return items
 }
@@ -1290,21 +1856,30 @@ func isSameDay(a time.Time, b time.Time) bool {
 	return ay == by && am == bm && ad == bd
 }
 
+// startSeatCountFetchForVisiblePage requests the seat counts and prices of
+// the sessions on the visible page that were not requested yet.
 func (m *appModel) startSeatCountFetchForVisiblePage() tea.Cmd {
 	ids := m.pendingSeatCountSessionIDsOnCurrentPage()
-	if len(ids) == 0 {
+	priceIDs := m.pendingPriceSessionIDsOnCurrentPage()
+	if len(ids) == 0 && len(priceIDs) == 0 {
 		return nil
 	}
 
-	cmds := make([]tea.Cmd, 0, len(ids))
+	cmds := make([]tea.Cmd, 0, len(ids)+len(priceIDs))
 	for _, id := range ids {
 		m.seatCounts[id] = seatCount{}
 		cmds = append(cmds, m.fetchSeatCountCmd(id))
 	}
+	for _, id := range priceIDs {
+		m.sessionPrices[id] = sessionPrices{}
+		cmds = append(cmds, m.fetchSessionPricesCmd(id))
+	}
 	return tea.Batch(cmds...)
 }
 
-func (m appModel) pendingSeatCountSessionIDsOnCurrentPage() []string {
+// sessionsOnCurrentPage returns the session items on the visible page of the
+// session list.
+func (m appModel) sessionsOnCurrentPage() []sessionItem {
 	if m.state != stateShowSessions {
 		return nil
 	}
@@ -1318,10 +1893,19 @@ func (m appModel) pendingSeatCountSessionIDsOnCurrentPage() []string {
 		return nil
 	}
 
-	ids := make([]string, 0, end-start)
+	sessions := make([]sessionItem, 0, end-start)
 	for _, item := range items[start:end] {
-		si, ok := item.(sessionItem)
-		if !ok || !si.session.HasSeatSelection || si.session.Id == "" {
+		if si, ok := item.(sessionItem); ok {
+			sessions = append(sessions, si)
+		}
+	}
+	return sessions
+}
+
+func (m appModel) pendingSeatCountSessionIDsOnCurrentPage() []string {
+	var ids []string
+	for _, si := range m.sessionsOnCurrentPage() {
+		if !si.session.HasSeatSelection || si.session.Id == "" {
 			continue
 		}
 		if _, alreadyRequested := m.seatCounts[si.session.Id]; alreadyRequested {
@@ -1334,14 +1918,14 @@ func (m appModel) pendingSeatCountSessionIDsOnCurrentPage() []string {
 
 func (m appModel) fetchSeatCountCmd(sessionID string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := m.requestContext()
 		detail, err := m.client.GetSessionDetails(ctx, sessionID)
 		if err != nil {
-			return seatCountMsg{sessionID: sessionID, count: seatCount{loaded: true, err: err}}
+			return seatCountMsg{gen: m.requestGen, sessionID: sessionID, count: seatCount{loaded: true, err: err}}
 		}
-		sections := filterSeatSections(detail.Sections)
+		sections := finder.SeatSections(detail.Sections)
 		if len(sections) == 0 {
-			return seatCountMsg{sessionID: sessionID, count: seatCount{loaded: true}}
+			return seatCountMsg{gen: m.requestGen, sessionID: sessionID, count: seatCount{loaded: true}}
 		}
 		var total seatCount
 		total.loaded = true
@@ -1351,16 +1935,9 @@ func (m appModel) fetchSeatCountCmd(sessionID string) tea.Cmd {
 				total.err = err
 				continue
 			}
-			part := computeSeatCounts(seatMap)
-			total.available += part.available
-			total.occupied += part.occupied
-			total.blocked += part.blocked
-			total.total += part.total
-			total.nonIdealAvailable += part.nonIdealAvailable
-			total.idealAvailable += part.idealAvailable
-			total.pairAvailable += part.pairAvailable
+			total.SeatSummary = total.SeatSummary.Add(finder.CountSeats(seatMap))
 		}
-		return seatCountMsg{sessionID: sessionID, count: total}
+		return seatCountMsg{gen: m.requestGen, sessionID: sessionID, count: total}
 	}
 }
 
@@ -1379,47 +1956,6 @@ func (m *appModel) updateSessionCount(sessionID string, count seatCount) tea.Cmd
 	return nil
 }
 
-func computeSeatCounts(seatMap model.SeatMap) seatCount {
-	var result seatCount
-	front := frontLineSet(seatMap, 3)
-	availableCols := map[int][]int{}
-	for _, line := range seatMap.Lines {
-		for _, seat := range line.Seats {
-			result.total++
-			status := strings.ToLower(seat.Status)
-			switch status {
-			case "available":
-				result.available++
-				if front[seat.Line] {
-					result.nonIdealAvailable++
-				}
-				if seat.Column > 0 {
-					availableCols[seat.Line] = append(availableCols[seat.Line], seat.Column)
-				}
-			case "occupied":
-				result.occupied++
-			case "blocked", "unavailable":
-				result.blocked++
-			}
-		}
-	}
-	result.idealAvailable = max(0, result.available-result.nonIdealAvailable)
-	result.pairAvailable = countAdjacentPairsFromCols(availableCols)
-	return result
-}
-
-type sessionWithTheater struct {
-	session     model.TheaterSession
-	theater     model.Theater
-	hasDistance bool
-	distanceKM  float64
-}
-
-type movieAggregate struct {
-	movie    model.TheaterMovie
-	sessions []sessionWithTheater
-}
-
 type cityItem struct {
 	city   model.City
 	recent bool
@@ -1451,6 +1987,8 @@ type theaterItem struct {
 	recent      bool
 	hasDistance bool
 	distanceKM  float64
+	// showCity labels the theater with its city when browsing a city group.
+	showCity bool
 }
 
 func (t theaterItem) Title() string {
@@ -1462,6 +2000,9 @@ func (t theaterItem) Description() string {
 	if t.recent {
 		parts = append(parts, "Recent")
 	}
+	if t.showCity && t.theater.City != "" {
+		parts = append(parts, t.theater.City)
+	}
 	if t.theater.Neighborhood != "" {
 		parts = append(parts, t.theater.Neighborhood)
 	} else if t.theater.Address != "" {
@@ -1474,13 +2015,13 @@ func (t theaterItem) Description() string {
 }
 
 func (t theaterItem) FilterValue() string {
-	return strings.ToLower(strings.Join([]string{t.theater.Name, t.theater.Neighborhood, t.theater.Address}, " "))
+	return strings.ToLower(strings.Join([]string{t.theater.Name, t.theater.Neighborhood, t.theater.Address, t.theater.City}, " "))
 }
 
 type movieItem struct {
 	movie          model.TheaterMovie
 	count          int
-	globalSessions []sessionWithTheater
+	globalSessions []finder.SessionWithTheater
 }
 
 func (m movieItem) Title() string {
@@ -1504,6 +2045,7 @@ type sessionItem struct {
 	hasDistance bool
 	distanceKM  float64
 	count       seatCount
+	prices      sessionPrices
 }
 
 func (s sessionItem) Title() string {
@@ -1520,8 +2062,6 @@ func (s sessionItem) Title() string {
 
 func (s sessionItem) Description() string {
 	types := formatSessionTypes(s.session.Type)
-	full := formatPrice(s.session.Price)
-	half := formatPrice(halfPrice(s.session.Price))
 	prefix := ""
 	if s.hasDistance {
 		prefix = fmt.Sprintf("%.1f km • ", s.distanceKM)
@@ -1530,16 +2070,16 @@ func (s sessionItem) Description() string {
 	if s.session.HasSeatSelection {
 		seatHint = " • seats ..."
 		if s.count.loaded && s.count.err == nil {
-			if s.count.nonIdealAvailable > 0 {
-				seatHint = fmt.Sprintf(" • seats %d (ideal %d • front %d • pairs %d)", s.count.available, s.count.idealAvailable, s.count.nonIdealAvailable, s.count.pairAvailable)
+			if s.count.NonIdealAvailable > 0 {
+				seatHint = fmt.Sprintf(" • seats %d (ideal %d • front %d • pairs %d)", s.count.Available, s.count.IdealAvailable, s.count.NonIdealAvailable, s.count.PairAvailable)
 			} else {
-				seatHint = fmt.Sprintf(" • seats %d (ideal %d • pairs %d)", s.count.available, s.count.idealAvailable, s.count.pairAvailable)
+				seatHint = fmt.Sprintf(" • seats %d (ideal %d • pairs %d)", s.count.Available, s.count.IdealAvailable, s.count.PairAvailable)
 			}
 		} else if s.count.err != nil {
 			seatHint = " • seats n/a"
 		}
 	}
-	return fmt.Sprintf("%s%s • Full %s • Half %s%s", prefix, types, full, half, seatHint)
+	return fmt.Sprintf("%s%s • %s%s", prefix, types, s.priceLabel(), seatHint)
 }
 
 func (s sessionItem) FilterValue() string {
@@ -1552,6 +2092,7 @@ type theaterVisibilityItem struct {
 	hidden      bool
 	hasDistance bool
 	distanceKM  float64
+	showCity    bool
 }
 
 func (t theaterVisibilityItem) Title() string {
@@ -1563,6 +2104,9 @@ func (t theaterVisibilityItem) Title() string {
 
 func (t theaterVisibilityItem) Description() string {
 	parts := []string{}
+	if t.showCity && t.theater.City != "" {
+		parts = append(parts, t.theater.City)
+	}
 	if t.theater.Neighborhood != "" {
 		parts = append(parts, t.theater.Neighborhood)
 	}
@@ -1632,20 +2176,13 @@ func buildCityItems(cities []model.City) []list.Item {
 func buildTheaterItems(theaters []model.Theater, cityID string, hidden map[string]bool, userLocation *service.UserLocation) []list.Item {
 	recents, _ := store.LoadRecentTheaters()
 
-	visible := make([]model.Theater, 0, len(theaters))
-	for _, theater := range theaters {
-		if hidden[theater.Id] {
-			continue
-		}
-		visible = append(visible, theater)
-	}
-
+	visible := finder.VisibleTheaters(theaters, hidden)
 	items := make([]list.Item, 0, len(visible))
 
 	if userLocation != nil {
-		sortTheatersByDistance(visible, userLocation)
+		finder.SortTheatersByDistance(visible, userLocation)
 		for _, theater := range visible {
-			distance, hasDistance := theaterDistanceKM(theater, userLocation)
+			distance, hasDistance := finder.TheaterDistanceKM(theater, userLocation)
 			items = append(items, theaterItem{
 				theater:     theater,
 				recent:      isRecentTheater(theater, cityID, recents),
@@ -1703,7 +2240,7 @@ func buildTheaterItems(theaters []model.Theater, cityID string, hidden map[strin
 func buildTheaterVisibilityItems(theaters []model.Theater, hidden map[string]bool, userLocation *service.UserLocation) []list.Item {
 	sorted := append([]model.Theater{}, theaters...)
 	if userLocation != nil {
-		sortTheatersByDistance(sorted, userLocation)
+		finder.SortTheatersByDistance(sorted, userLocation)
 	} else {
 		sort.Slice(sorted, func(i, j int) bool {
 			return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
@@ -1712,7 +2249,7 @@ func buildTheaterVisibilityItems(theaters []model.Theater, hidden map[string]boo
 
 	items := make([]list.Item, 0, len(sorted))
 	for _, theater := range sorted {
-		distance, hasDistance := theaterDistanceKM(theater, userLocation)
+		distance, hasDistance := finder.TheaterDistanceKM(theater, userLocation)
 		items = append(items, theaterVisibilityItem{
 			theater:     theater,
 			hidden:      hidden[theater.Id],
@@ -1739,13 +2276,26 @@ func buildMovieItems(day model.TheaterSessionDay) []list.Item {
 	return items
 }
 
-func buildMovieItemsFromCatalog(movies []movieAggregate) []list.Item {
+// catalogListTitle names the cross-theater movie list, counting the theaters
+// that failed and the ones without sessions on the day, like the find command.
+func catalogListTitle(failed, ignored int) string {
+	title := "Select Movie • All Theaters"
+	if failed > 0 {
+		title += fmt.Sprintf(" • %d failed", failed)
+	}
+	if ignored > 0 {
+		title += fmt.Sprintf(" • %d without sessions", ignored)
+	}
+	return title
+}
+
+func buildMovieItemsFromCatalog(movies []finder.MovieAggregate) []list.Item {
 	items := make([]list.Item, 0, len(movies))
 	for _, movie := range movies {
 		items = append(items, movieItem{
-			movie:          movie.movie,
-			count:          len(movie.sessions),
-			globalSessions: movie.sessions,
+			movie:          movie.Movie,
+			count:          len(movie.Sessions),
+			globalSessions: movie.Sessions,
 		})
 	}
 	sort.Slice(items, func(i, j int) bool {
@@ -1757,19 +2307,7 @@ func buildMovieItemsFromCatalog(movies []movieAggregate) []list.Item {
 }
 
 func buildSessionItems(movie model.TheaterMovie, counts map[string]seatCount) ([]list.Item, []model.TheaterSession) {
-	var sessions []model.TheaterSession
-	for _, room := range movie.Rooms {
-		for _, session := range room.Sessions {
-			if strings.TrimSpace(session.Room) == "" {
-				session.Room = room.Name
-			}
-			sessions = append(sessions, session)
-		}
-	}
-	sort.Slice(sessions, func(i, j int) bool {
-		return sessions[i].Date.LocalDate.Before(sessions[j].Date.LocalDate)
-	})
-
+	sessions := finder.MovieSessions(movie)
 	items := make([]list.Item, 0, len(sessions))
 	for _, session := range sessions {
 		count := counts[session.Id]
@@ -1778,54 +2316,57 @@ func buildSessionItems(movie model.TheaterMovie, counts map[string]seatCount) ([
 	return items, sessions
 }
 
-func buildGlobalSessionItems(sessions []sessionWithTheater, counts map[string]seatCount) ([]list.Item, []model.TheaterSession) {
-	sorted := append([]sessionWithTheater{}, sessions...)
-	sort.Slice(sorted, func(i, j int) bool {
-		a := sorted[i]
-		b := sorted[j]
+func buildGlobalSessionItems(sessions []finder.SessionWithTheater, counts map[string]seatCount) ([]list.Item, []model.TheaterSession) {
+	sorted := append([]finder.SessionWithTheater{}, sessions...)
+	finder.SortSessionsByDistance(sorted)
 
-		if a.hasDistance && b.hasDistance && math.Abs(a.distanceKM-b.distanceKM) > 1e-6 {
-			return a.distanceKM < b.distanceKM
-		}
-		if a.hasDistance != b.hasDistance {
-			return a.hasDistance
-		}
-		if !a.session.Date.LocalDate.Equal(b.session.Date.LocalDate) {
-			return a.session.Date.LocalDate.Before(b.session.Date.LocalDate)
-		}
-		return strings.ToLower(a.theater.Name) < strings.ToLower(b.theater.Name)
-	})
+	// Sessions from a city group name the city of their theater.
+	cities := map[string]bool{}
+	for _, entry := range sorted {
+		cities[entry.Theater.CityId] = true
+	}
 
 	items := make([]list.Item, 0, len(sorted))
 	plain := make([]model.TheaterSession, 0, len(sorted))
 	for _, entry := range sorted {
-		count := counts[entry.session.Id]
+		count := counts[entry.Session.Id]
+		theaterName := entry.Theater.Name
+		if len(cities) > 1 {
+			theaterName = theaterCityLabel(entry.Theater)
+		}
 		items = append(items, sessionItem{
-			session:     entry.session,
-			theaterName: entry.theater.Name,
-			hasDistance: entry.hasDistance,
-			distanceKM:  entry.distanceKM,
+			session:     entry.Session,
+			theaterName: theaterName,
+			hasDistance: entry.HasDistance,
+			distanceKM:  entry.DistanceKM,
 			count:       count,
 		})
-		plain = append(plain, entry.session)
+		plain = append(plain, entry.Session)
 	}
 	return items, plain
 }
 
 func (m *appModel) refreshTheaterLists() {
-	m.theaterList.SetItems(buildTheaterItems(m.theaters, m.city.Id, m.hiddenTheaters, m.userLocation))
-	m.theaterPref.SetItems(buildTheaterVisibilityItems(m.theaters, m.hiddenTheaters, m.userLocation))
+	items := buildTheaterItems(m.theaters, m.city.Id, m.hiddenTheaters, m.userLocation)
+	prefs := buildTheaterVisibilityItems(m.theaters, m.hiddenTheaters, m.userLocation)
+	if len(m.cityGroup.Cities) > 0 {
+		for i, item := range items {
+			ti := item.(theaterItem)
+			ti.showCity = true
+			items[i] = ti
+		}
+		for i, item := range prefs {
+			vi := item.(theaterVisibilityItem)
+			vi.showCity = true
+			prefs[i] = vi
+		}
+	}
+	m.theaterList.SetItems(items)
+	m.theaterPref.SetItems(prefs)
 }
 
 func (m appModel) visibleTheaters() []model.Theater {
-	visible := make([]model.Theater, 0, len(m.theaters))
-	for _, theater := range m.theaters {
-		if m.hiddenTheaters[theater.Id] {
-			continue
-		}
-		visible = append(visible, theater)
-	}
-	return visible
+	return finder.VisibleTheaters(m.theaters, m.hiddenTheaters)
 }
 
 func (m appModel) openMovieAcrossTheaters() (tea.Model, tea.Cmd, bool) {
@@ -1835,14 +2376,21 @@ func (m appModel) openMovieAcrossTheaters() (tea.Model, tea.Cmd, bool) {
 	}
 	m.browsingAllTheaters = true
 	m.theater = model.Theater{}
+	m.beginRequests()
 	m.state = stateLoadingSessions
 	return m, tea.Batch(m.fetchMovieCatalogCmd(m.city.Id, visible, m.date), m.spinner.Tick), true
 }
 
 func (m appModel) advanceToNextDayFromError() (tea.Model, tea.Cmd, bool) {
 	m.date = truncateDate(m.date.AddDate(0, 0, 1))
-	m.state = stateLoadingSessions
 	m.errorSuggestNextDay = false
+	return m.reloadDay()
+}
+
+// reloadDay loads the movies of m.date in the theaters being browsed.
+func (m appModel) reloadDay() (tea.Model, tea.Cmd, bool) {
+	m.beginRequests()
+	m.state = stateLoadingSessions
 
 	if m.browsingAllTheaters {
 		visible := m.visibleTheaters()
@@ -1852,10 +2400,11 @@ func (m appModel) advanceToNextDayFromError() (tea.Model, tea.Cmd, bool) {
 		return m, tea.Batch(m.fetchMovieCatalogCmd(m.city.Id, visible, m.date), m.spinner.Tick), true
 	}
 
-	if m.city.Id == "" || m.theater.Id == "" {
+	cityID := m.theaterCityID(m.theater)
+	if cityID == "" || m.theater.Id == "" {
 		return m, errWithOptionsCmd(errors.New("select a theater before trying another date"), stateSelectTheater, false), true
 	}
-	return m, tea.Batch(m.fetchSessionsCmd(m.city.Id, m.theater.Id, m.date), m.spinner.Tick), true
+	return m, tea.Batch(m.fetchSessionsCmd(cityID, m.theater.Id, m.date), m.spinner.Tick), true
 }
 
 func (m appModel) toggleTheaterVisibility() (tea.Model, tea.Cmd, bool) {
@@ -1864,7 +2413,7 @@ func (m appModel) toggleTheaterVisibility() (tea.Model, tea.Cmd, bool) {
 		return m, nil, true
 	}
 	hidden := !item.hidden
-	if err := store.SetTheaterHidden(m.city.Id, item.theater.Id, hidden); err != nil {
+	if err := store.SetTheaterHidden(m.theaterCityID(item.theater), item.theater.Id, hidden); err != nil {
 		return m, errCmd(err), true
 	}
 	if m.hiddenTheaters == nil {
@@ -1890,72 +2439,6 @@ func (m appModel) toggleTheaterVisibility() (tea.Model, tea.Cmd, bool) {
 	return m, nil, true
 }
 
-func aggregateMovieCatalog(results <-chan theaterSessionsResult, date time.Time, userLocation *service.UserLocation) ([]movieAggregate, int, int) {
-	byMovie := map[string]*movieAggregate{}
-	failed := 0
-	ignored := 0
-
-	for result := range results {
-		if result.err != nil {
-			if service.IsNotFound(result.err) {
-				ignored++
-				continue
-			}
-			failed++
-			continue
-		}
-
-		day := selectDay(result.days, date)
-		if len(day.Movies) == 0 {
-			ignored++
-			continue
-		}
-
-		for _, movie := range day.Movies {
-			key := movieAggregateKey(movie)
-			entry := byMovie[key]
-			if entry == nil {
-				copyMovie := movie
-				copyMovie.Rooms = nil
-				entry = &movieAggregate{movie: copyMovie}
-				byMovie[key] = entry
-			}
-			for _, room := range movie.Rooms {
-				for _, session := range room.Sessions {
-					if strings.TrimSpace(session.Room) == "" {
-						session.Room = room.Name
-					}
-					distance, hasDistance := theaterDistanceKM(result.theater, userLocation)
-					entry.sessions = append(entry.sessions, sessionWithTheater{
-						session:     session,
-						theater:     result.theater,
-						hasDistance: hasDistance,
-						distanceKM:  distance,
-					})
-				}
-			}
-		}
-	}
-
-	movies := make([]movieAggregate, 0, len(byMovie))
-	for _, movie := range byMovie {
-		movies = append(movies, *movie)
-	}
-	sort.Slice(movies, func(i, j int) bool {
-		return strings.ToLower(movies[i].movie.Title) < strings.ToLower(movies[j].movie.Title)
-	})
-	return movies, failed, ignored
-}
-
-func movieAggregateKey(movie model.TheaterMovie) string {
-	if strings.TrimSpace(movie.Id) != "" {
-		return "id:" + movie.Id
-	}
-	title := strings.ToLower(strings.TrimSpace(movie.Title))
-	orig := strings.ToLower(strings.TrimSpace(movie.OriginalTitle))
-	return title + "|" + orig
-}
-
 func isRecentTheater(theater model.Theater, cityID string, recents []store.RecentTheater) bool {
 	for _, recent := range recents {
 		if recent.CityID != "" && cityID != "" && recent.CityID != cityID {
@@ -1971,31 +2454,6 @@ func isRecentTheater(theater model.Theater, cityID string, recents []store.Recen
 	return false
 }
 
-func sortTheatersByDistance(theaters []model.Theater, location *service.UserLocation) {
-	sort.Slice(theaters, func(i, j int) bool {
-		leftDist, leftOK := theaterDistanceKM(theaters[i], location)
-		rightDist, rightOK := theaterDistanceKM(theaters[j], location)
-		if leftOK && rightOK && math.Abs(leftDist-rightDist) > 1e-6 {
-			return leftDist < rightDist
-		}
-		if leftOK != rightOK {
-			return leftOK
-		}
-		return strings.ToLower(theaters[i].Name) < strings.ToLower(theaters[j].Name)
-	})
-}
-
-func theaterDistanceKM(theater model.Theater, location *service.UserLocation) (float64, bool) {
-	if location == nil {
-		return 0, false
-	}
-	if theater.Geolocation.Lat == 0 && theater.Geolocation.Lng == 0 {
-		return 0, false
-	}
-	distance := haversineKM(location.Latitude, location.Longitude, theater.Geolocation.Lat, theater.Geolocation.Lng)
-	return distance, true
-}
-
 func locationLabel(location *service.UserLocation) string {
 	if location == nil {
 		return ""
@@ -2030,24 +2488,6 @@ func locationSourceLabel(source string) string {
 	}
 }
 
-func haversineKM(lat1 float64, lon1 float64, lat2 float64, lon2 float64) float64 {
-	const earthRadius = 6371.0
-	toRad := math.Pi / 180
-
-	lat1Rad := lat1 * toRad
-	lon1Rad := lon1 * toRad
-	lat2Rad := lat2 * toRad
-	lon2Rad := lon2 * toRad
-
-	dLat := lat2Rad - lat1Rad
-	dLon := lon2Rad - lon1Rad
-
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
-		math.Cos(lat1Rad)*math.Cos(lat2Rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-	return earthRadius * c
-}
-
 type sectionItem struct {
 	section model.SessionSection
 }
@@ -2078,375 +2518,10 @@ func buildSectionItems(sections []model.SessionSection) []list.Item {
 	return items
 }
 
-func filterSeatSections(sections []model.SessionSection) []model.SessionSection {
-	var filtered []model.SessionSection
-	for _, section := range sections {
-		if section.HasSeatSelection {
-			filtered = append(filtered, section)
-		}
-	}
-	return filtered
-}
-
-func selectDay(days []model.TheaterSessionDay, date time.Time) model.TheaterSessionDay {
-	if len(days) == 0 {
-		return model.TheaterSessionDay{}
-	}
-	target := date.Format(time.DateOnly)
-	for _, day := range days {
-		if day.Date == target {
-			return day
-		}
-	}
-	return days[0]
-}
-
-func (m appModel) renderSeatMap() string {
-	if m.seatMap.Bounds.Lines == 0 || m.seatMap.Bounds.Columns == 0 {
-		return "No seat map data."
-	}
-
-	rows := m.seatMap.Bounds.Lines
-	cols := m.seatMap.Bounds.Columns
-
-	grid := make([][]seatCell, rows)
-	for i := range grid {
-		grid[i] = make([]seatCell, cols)
-		for j := range grid[i] {
-			grid[i][j] = seatCell{}
-		}
-	}
-
-	rowLabel := make(map[int]string)
-	frontRows := frontLineSet(m.seatMap, 3)
-	available := 0
-	occupied := 0
-	blocked := 0
-	nonIdealAvailable := 0
-	total := 0
-	minRow, maxRow := rows-1, 0
-	minCol, maxCol := cols-1, 0
-
-	for _, line := range m.seatMap.Lines {
-		for _, seat := range line.Seats {
-			r := seat.Line - 1
-			c := seat.Column - 1
-			if r < 0 || c < 0 || r >= rows || c >= cols {
-				continue
-			}
-			total++
-			minRow = min(minRow, r)
-			maxRow = max(maxRow, r)
-			minCol = min(minCol, c)
-			maxCol = max(maxCol, c)
-
-			if _, ok := rowLabel[r]; !ok {
-				rowLabel[r] = seatRowLabel(seat)
-			}
-			token, status := seatToken(seat)
-			switch status {
-			case "available":
-				available++
-			case "occupied":
-				occupied++
-			case "blocked":
-				blocked++
-			}
-			cell := seatCell{
-				token:  token,
-				status: status,
-				label:  seatNumberLabel(seat),
-				front:  frontRows[seat.Line],
-			}
-			if cell.front && status == "available" {
-				nonIdealAvailable++
-			}
-			grid[r][c] = cell
-		}
-	}
-
-	if total == 0 {
-		return "No seat map data."
-	}
-
-	rowWidth := 2
-	for _, label := range rowLabel {
-		if len(label) > rowWidth {
-			rowWidth = len(label)
-		}
-	}
-
-	maxLabelWidth := 2
-	if m.showSeatNumbers {
-		for r := minRow; r <= maxRow; r++ {
-			for c := minCol; c <= maxCol; c++ {
-				if l := len(grid[r][c].label); l > maxLabelWidth {
-					maxLabelWidth = l
-				}
-			}
-		}
-	}
-	cellWidth := max(2, maxLabelWidth)
-
-	var b strings.Builder
-	seatStyleAvailable := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
-	seatStyleOccupied := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
-	seatStyleBlocked := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-	seatStyleAccessible := lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
-	seatStyleFront := lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
-
-	for r := minRow; r <= maxRow; r++ {
-		label := rowLabel[r]
-		if label == "" {
-			label = fmt.Sprintf("%d", r+1)
-		}
-		b.WriteString(fmt.Sprintf("%*s ", rowWidth, label))
-		for c := minCol; c <= maxCol; c++ {
-			cell := grid[r][c]
-			text := cell.token
-			if m.showSeatNumbers && cell.label != "" {
-				text = cell.label
-			}
-			rendered := padCell(text, cellWidth)
-			switch cell.token {
-			case "[]":
-				if cell.front {
-					rendered = seatStyleFront.Render(rendered)
-				} else {
-					rendered = seatStyleAvailable.Render(rendered)
-				}
-			case "XX":
-				rendered = seatStyleOccupied.Render(rendered)
-			case "##":
-				rendered = seatStyleBlocked.Render(rendered)
-			case "DD":
-				rendered = seatStyleAccessible.Render(rendered)
-			}
-			b.WriteString(rendered)
-			if c < maxCol {
-				b.WriteString(" ")
-			}
-		}
-		b.WriteString(fmt.Sprintf(" %*s\n", rowWidth, label))
-	}
-
-	gridWidth := (maxCol-minCol+1)*(cellWidth+1) - 1
-	screenStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("0")).
-		Background(lipgloss.Color("214"))
-	screenBorderStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("214")).
-		Background(lipgloss.Color("236"))
-
-	screenBar := screenBarBlock(gridWidth, "SCREEN")
-
-	b.WriteString("\n")
-	b.WriteString(strings.Repeat(" ", rowWidth+1))
-	b.WriteString(screenBorderStyle.Render(screenBar.top))
-	b.WriteString("\n")
-	b.WriteString(strings.Repeat(" ", rowWidth+1))
-	b.WriteString(screenStyle.Render(screenBar.mid))
-	b.WriteString("\n")
-	b.WriteString(strings.Repeat(" ", rowWidth+1))
-	b.WriteString(screenBorderStyle.Render(screenBar.bot))
-	b.WriteString("\n")
-	b.WriteString(strings.Repeat(" ", rowWidth+1))
-	b.WriteString(hint("Front / Screen"))
-	b.WriteString("\n\n")
-
-	// Styles for Legend
-	availStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))   // Green
-	occStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))    // Red
-	accessStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")) // Orange
-	blockStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))  // Grey
-	frontStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))  // Yellow
-
-	legendParts := []string{
-		availStyle.Render("● Livre"),
-		occStyle.Render("✖ Ocupado"),
-		accessStyle.Render("♿ Acessível"),
-		blockStyle.Render("◼ Bloqueado"),
-		frontStyle.Render("▼ Frente"),
-	}
-	legend := strings.Join(legendParts, "  ")
-
-	if m.showSeatNumbers {
-		legend += "  " + lipgloss.NewStyle().Faint(true).Render("(Números ativados)")
-	}
-
-	percent := float64(available) / float64(max(1, total)) * 100
-	ideal := max(0, available-nonIdealAvailable)
-	pairs := countAdjacentPairs(m.seatMap)
-
-	counts := fmt.Sprintf(
-		"Disponíveis: %d (%d ideais)  •  Duplas: %d  •  Total: %d (%.0f%%)",
-		available, ideal, pairs, total, percent,
-	)
-
-	return b.String() + "\n" + legend + "\n" + hint(counts)
-}
-
-func seatToken(seat model.Seat) (string, string) {
-	switch strings.ToLower(seat.Status) {
-	case "occupied":
-		return "XX", "occupied"
-	case "available":
-		if strings.EqualFold(seat.Type, "Disability") {
-			return "DD", "available"
-		}
-		return "[]", "available"
-	case "blocked", "unavailable":
-		return "##", "blocked"
-	default:
-		return "  ", "unknown"
-	}
-}
-
-type seatCell struct {
-	token  string
-	status string
-	label  string
-	front  bool
-}
-
-func seatRowLabel(seat model.Seat) string {
-	parts := strings.Fields(strings.TrimSpace(seat.Label))
-	if len(parts) > 0 {
-		first := parts[0]
-		if len(first) == 1 && first[0] >= 'A' && first[0] <= 'Z' {
-			return first
-		}
-	}
-	if seat.Line > 0 {
-		return fmt.Sprintf("%d", seat.Line)
-	}
-	return ""
-}
-
-func seatNumberLabel(seat model.Seat) string {
-	parts := strings.Fields(strings.TrimSpace(seat.Label))
-	if len(parts) == 0 {
-		return ""
-	}
-	if len(parts) == 1 {
-		return parts[0]
-	}
-	return parts[len(parts)-1]
-}
-
-func frontLineSet(seatMap model.SeatMap, count int) map[int]bool {
-	lines := map[int]bool{}
-	for _, line := range seatMap.Lines {
-		for _, seat := range line.Seats {
-			if seat.Line > 0 {
-				lines[seat.Line] = true
-			}
-		}
-	}
-	if len(lines) == 0 {
-		return map[int]bool{}
-	}
-	var keys []int
-	for k := range lines {
-		keys = append(keys, k)
-	}
-	sort.Ints(keys)
-	if count > len(keys) {
-		count = len(keys)
-	}
-	front := make(map[int]bool, count)
-	for i := len(keys) - count; i < len(keys); i++ {
-		front[keys[i]] = true
-	}
-	return front
-}
-
-func countAdjacentPairs(seatMap model.SeatMap) int {
-	cols := map[int][]int{}
-	for _, line := range seatMap.Lines {
-		for _, seat := range line.Seats {
-			if strings.ToLower(seat.Status) != "available" {
-				continue
-			}
-			if seat.Column > 0 {
-				cols[seat.Line] = append(cols[seat.Line], seat.Column)
-			}
-		}
-	}
-	return countAdjacentPairsFromCols(cols)
-}
-
-func countAdjacentPairsFromCols(cols map[int][]int) int {
-	count := 0
-	for _, list := range cols {
-		if len(list) == 0 {
-			continue
-		}
-		sort.Ints(list)
-		for i := 0; i < len(list)-1; {
-			if list[i]+1 == list[i+1] {
-				count++
-				i += 2
-				continue
-			}
-			i++
-		}
-	}
-	return count
-}
-
-func padCell(text string, width int) string {
-	if width <= 0 {
-		return ""
-	}
-	if text == "" {
-		return strings.Repeat(" ", width)
-	}
-	if len(text) >= width {
-		return text[:width]
-	}
-	padding := width - len(text)
-	left := padding / 2
-	right := padding - left
-	return strings.Repeat(" ", left) + text + strings.Repeat(" ", right)
-}
-
 type seatCount struct {
-	available         int
-	occupied          int
-	blocked           int
-	total             int
-	nonIdealAvailable int
-	idealAvailable    int
-	pairAvailable     int
-	loaded            bool
-	err               error
-}
-
-type screenBlock struct {
-	top string
-	mid string
-	bot string
-}
-
-func screenBarBlock(width int, label string) screenBlock {
-	if width < len(label)+4 {
-		width = len(label) + 4
-	}
-	if width < 10 {
-		width = 10
-	}
-
-	border := "╭" + strings.Repeat("─", width-2) + "╮"
-	bottom := "╰" + strings.Repeat("─", width-2) + "╯"
-
-	labelText := " " + label + " "
-	padding := width - len(labelText) - 2
-	left := padding / 2
-	right := padding - left
-	mid := "│" + strings.Repeat(" ", left) + labelText + strings.Repeat(" ", right) + "│"
-	return screenBlock{top: border, mid: mid, bot: bottom}
+	finder.SeatSummary
+	loaded bool
+	err    error
 }
 
 func formatSessionTypes(types []string) string {
@@ -2475,10 +2550,3 @@ func formatPrice(price float64) string {
 	}
 	return fmt.Sprintf("R$ %.2f", price)
 }
-
-func halfPrice(price float64) float64 {
-	if price <= 0 {
-		return 0
-	}
-	return price / 2
-}
@@ -1,12 +1,15 @@
 package tui
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/internal/ingressotest"
 	"ingresso-finder-cli/model"
 	"ingresso-finder-cli/service"
 	"ingresso-finder-cli/store"
@@ -28,14 +31,6 @@ func newFilterModel(items []list.Item) *appModel {
 	return &model
 }
 
-func setStoreIsolationEnv(t *testing.T) {
-	t.Helper()
-	root := t.TempDir()
-	t.Setenv("HOME", root)
-	t.Setenv("XDG_CONFIG_HOME", root)
-	t.Setenv("XDG_CACHE_HOME", root)
-}
-
 func TestHandleFilterInput_AppendsRunes(t *testing.T) {
 	m := newFilterModel([]list.Item{
 		testItem{value: "Barueri"},
@@ -97,7 +92,7 @@ func TestHandleFilterInput_Space(t *testing.T) {
 }
 
 func TestBuildTheaterItems_HiddenTheatersAreExcluded(t *testing.T) {
-	setStoreIsolationEnv(t)
+	ingressotest.IsolateStore(t)
 
 	theaters := []model.Theater{
 		{Id: "1", Name: "Cinema A"},
@@ -118,7 +113,7 @@ func TestBuildTheaterItems_HiddenTheatersAreExcluded(t *testing.T) {
 }
 
 func TestBuildTheaterItems_SortsByDistanceWhenLocationExists(t *testing.T) {
-	setStoreIsolationEnv(t)
+	ingressotest.IsolateStore(t)
 
 	near := model.Theater{Id: "near", Name: "Near Cinema"}
 	near.Geolocation.Lat = -23.5505
@@ -164,7 +159,7 @@ func TestLocationLabel_IncludesIPSource(t *testing.T) {
 }
 
 func TestHandleFilterInput_TheaterScreenAcceptsNumericFilter(t *testing.T) {
-	setStoreIsolationEnv(t)
+	ingressotest.IsolateStore(t)
 
 	app := New().(appModel)
 	app.state = stateSelectTheater
@@ -270,6 +265,15 @@ func TestOpenMovieAcrossTheaters_EnablesGlobalMode(t *testing.T) {
 	}
 }
 
+func TestMovieCatalog_TitleCountsFailedAndIgnoredTheaters(t *testing.T) {
+	app := New().(appModel)
+	movies := []finder.MovieAggregate{{Movie: model.TheaterMovie{Id: "m1", Title: "Duna: Parte 2"}}}
+	updated, _ := app.Update(movieCatalogMsg{gen: app.requestGen, movies: movies, failed: 1, ignored: 3})
+	if title := updated.(appModel).movieList.Title; !strings.Contains(title, "1 failed") || !strings.Contains(title, "3 without sessions") {
+		t.Fatalf("unexpected catalog title: %q", title)
+	}
+}
+
 func TestEnterTheaterItem_StartsTheaterFlow(t *testing.T) {
 	app := New().(appModel)
 	app.state = stateSelectTheater
@@ -305,7 +309,7 @@ func TestTheaterScreen_AcceptsRuneInputWithoutChangingState(t *testing.T) {
 }
 
 func TestStartupRecentCity_UsesMostRecent(t *testing.T) {
-	setStoreIsolationEnv(t)
+	ingressotest.IsolateStore(t)
 
 	if err := store.RememberCity(model.City{Id: "3558", Name: "Sao Paulo", Uf: "SP"}); err != nil {
 		t.Fatalf("remember city: %v", err)
@@ -321,7 +325,7 @@ func TestStartupRecentCity_UsesMostRecent(t *testing.T) {
 }
 
 func TestCityFromRecentCache_ByID(t *testing.T) {
-	setStoreIsolationEnv(t)
+	ingressotest.IsolateStore(t)
 
 	cities := []model.City{
 		{Id: "3558", Name: "Sao Paulo", Uf: "SP"},
@@ -369,46 +373,402 @@ type errTest string
 
 func (e errTest) Error() string { return string(e) }
 
-func TestAggregateMovieCatalog_MergesSameMovieAcrossTheaters(t *testing.T) {
-	date := time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)
-	sessionA := model.TheaterSession{Id: "s1"}
-	sessionA.Date.LocalDate = date.Add(19 * time.Hour)
-	sessionB := model.TheaterSession{Id: "s2"}
-	sessionB.Date.LocalDate = date.Add(21 * time.Hour)
-
-	results := make(chan theaterSessionsResult, 2)
-	results <- theaterSessionsResult{
-		theater: model.Theater{Id: "t1", Name: "Cinema 1"},
-		days: []model.TheaterSessionDay{{
-			Date: date.Format(time.DateOnly),
-			Movies: []model.TheaterMovie{{
-				Id:    "m1",
-				Title: "Movie X",
-				Rooms: []model.TheaterRoom{{Name: "Room 1", Sessions: []model.TheaterSession{sessionA}}},
-			}},
-		}},
-	}
-	results <- theaterSessionsResult{
-		theater: model.Theater{Id: "t2", Name: "Cinema 2"},
-		days: []model.TheaterSessionDay{{
-			Date: date.Format(time.DateOnly),
-			Movies: []model.TheaterMovie{{
-				Id:    "m1",
-				Title: "Movie X",
-				Rooms: []model.TheaterRoom{{Name: "Room 2", Sessions: []model.TheaterSession{sessionB}}},
-			}},
-		}},
-	}
-	close(results)
-
-	movies, failed, ignored := aggregateMovieCatalog(results, date, nil)
-	if failed != 0 || ignored != 0 {
-		t.Fatalf("expected no failures/ignored, got failed=%d ignored=%d", failed, ignored)
-	}
-	if len(movies) != 1 {
-		t.Fatalf("expected 1 merged movie, got %d", len(movies))
-	}
-	if len(movies[0].sessions) != 2 {
-		t.Fatalf("expected 2 sessions in merged movie, got %d", len(movies[0].sessions))
+func TestSeatWatch_AlertsAndIgnoresStaleResults(t *testing.T) {
+	app := New().(appModel)
+	app.state = stateShowSeatMap
+
+	updated, cmd, _ := app.toggleSeatWatch()
+	app = updated.(appModel)
+	if !app.seatWatching || cmd == nil {
+		t.Fatal("expected w to start watching with a first check")
+	}
+
+	free := model.SeatMap{Lines: []model.SeatLine{{Line: 1, Seats: []model.Seat{
+		{Label: "A 1", Status: "Available", Line: 1, Column: 1},
+		{Label: "A 2", Status: "Available", Line: 1, Column: 2},
+	}}}}
+
+	next, _ := app.Update(seatWatchMsg{gen: app.seatWatchGen - 1, seatMap: free})
+	if stale := next.(appModel); !stale.seatWatching || stale.seatAlert != "" {
+		t.Fatal("expected a result from an older watch to be ignored")
+	}
+
+	next, _ = app.Update(seatWatchMsg{gen: app.seatWatchGen, seatMap: free})
+	app = next.(appModel)
+	if app.seatWatching {
+		t.Fatal("expected the watch to stop after alerting")
+	}
+	if !strings.Contains(app.seatAlert, "A1-A2") {
+		t.Fatalf("unexpected alert: %q", app.seatAlert)
+	}
+
+	next, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if kept := next.(appModel); kept.seatAlert == "" || !strings.Contains(kept.headerView(), "A1-A2") {
+		t.Fatal("expected the alert to survive other keys")
+	}
+	next, _ = app.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	if dismissed := next.(appModel); dismissed.seatAlert != "" {
+		t.Fatal("expected ctrl+x to dismiss the alert")
+	}
+}
+
+func TestSeatWatch_WaitsForChosenSeats(t *testing.T) {
+	app := New().(appModel)
+	app.state = stateShowSeatMap
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	app = updated.(appModel)
+	if !app.editingSeatLabels {
+		t.Fatal("expected s to open the seat prompt")
+	}
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("a 2,")},
+		{Type: tea.KeySpace},
+		{Type: tea.KeyRunes, Runes: []rune("a3")},
+		{Type: tea.KeyEnter},
+	} {
+		updated, _ = app.Update(key)
+		app = updated.(appModel)
+	}
+	if app.editingSeatLabels || strings.Join(app.seatWatchLabels, ",") != "A2,A3" {
+		t.Fatalf("expected the seats to be applied, got %q", app.seatWatchLabels)
+	}
+
+	updated, _, _ = app.toggleSeatWatch()
+	app = updated.(appModel)
+	seats := model.SeatMap{Lines: []model.SeatLine{{Line: 1, Seats: []model.Seat{
+		{Label: "A 1", Status: "Available", Line: 1, Column: 1},
+		{Label: "A 2", Status: "Occupied", Line: 1, Column: 2},
+		{Label: "A 3", Status: "Occupied", Line: 1, Column: 3},
+	}}}}
+	updated, _ = app.Update(seatWatchMsg{gen: app.seatWatchGen, seatMap: seats})
+	app = updated.(appModel)
+	if !app.seatWatching || app.seatAlert != "" {
+		t.Fatal("expected a free seat that was not chosen not to alert")
+	}
+
+	seats.Lines[0].Seats[2].Status = "Available"
+	updated, _ = app.Update(seatWatchMsg{gen: app.seatWatchGen, seatMap: seats})
+	app = updated.(appModel)
+	if app.seatWatching || !strings.Contains(app.seatAlert, "A3") {
+		t.Fatalf("expected an alert for A3, got %q", app.seatAlert)
+	}
+}
+
+func TestGoBack_CancelsRequestsAndDropsLateAnswers(t *testing.T) {
+	app := New().(appModel)
+	app.city = model.City{Id: "1"}
+	app.theaters = []model.Theater{{Id: "10", Name: "Cinema A"}}
+	app.hiddenTheaters = map[string]bool{}
+	app.state = stateSelectTheater
+
+	updated, _, _ := app.openMovieAcrossTheaters()
+	loading := updated.(appModel)
+	ctx := loading.requestContext()
+	staleGen := loading.requestGen
+
+	// Leave while the catalog is still loading.
+	if loading.state != stateLoadingSessions {
+		t.Fatalf("expected the catalog to be loading, got %v", loading.state)
+	}
+	updated, _ = loading.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	next := updated.(appModel)
+
+	if ctx.Err() == nil {
+		t.Fatal("expected leaving the screen to cancel its requests")
+	}
+	if next.state != stateSelectTheater {
+		t.Fatalf("expected stateSelectTheater, got %v", next.state)
+	}
+
+	movie := model.TheaterMovie{Title: "Duna"}
+	updated, _ = next.Update(movieCatalogMsg{gen: staleGen, movies: []finder.MovieAggregate{{Movie: movie}}})
+	next = updated.(appModel)
+	if next.state != stateSelectTheater || len(next.movieList.Items()) != 0 {
+		t.Fatalf("expected the late catalog to be ignored, got state %v with %d movies", next.state, len(next.movieList.Items()))
+	}
+}
+
+func TestBeginRequests_ForgetsPendingSeatCounts(t *testing.T) {
+	app := New().(appModel)
+	app.seatCounts["loading"] = seatCount{}
+	app.seatCounts["done"] = seatCount{loaded: true}
+
+	app.beginRequests()
+
+	if _, ok := app.seatCounts["loading"]; ok {
+		t.Fatal("expected the pending seat count to be requested again later")
+	}
+	if _, ok := app.seatCounts["done"]; !ok {
+		t.Fatal("expected loaded seat counts to be kept")
+	}
+}
+
+func TestMoviePage_ShowsIngressoDetails(t *testing.T) {
+	app := New().(appModel)
+	app.posterMode = posterOff
+	app.state = stateSelectMovie
+	app.movieList.SetItems([]list.Item{movieItem{movie: model.TheaterMovie{Id: "m1", Title: "Duna: Parte 2"}}})
+
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	page := updated.(appModel)
+	if page.state != stateShowMovie || cmd == nil {
+		t.Fatalf("expected the movie page to open and load, got state %v", page.state)
+	}
+	if !strings.Contains(page.renderMoviePage(), "Carregando ficha") {
+		t.Fatal("expected a loading message before the details arrive")
+	}
+	if _, tick := page.Update(page.spinner.Tick()); tick == nil {
+		t.Fatal("expected the spinner to keep ticking while the details load")
+	}
+
+	event := model.Event{
+		Id:       "m1",
+		Title:    "Duna: Parte 2",
+		Synopsis: "Paul Atreides se une a Chani.",
+		Director: "Denis Villeneuve",
+		Trailers: []model.EventTrailer{{Url: "https://youtu.be/duna"}},
+	}
+	updated, _ = page.Update(eventMsg{gen: page.requestGen, event: event})
+	page = updated.(appModel)
+	if _, tick := page.Update(page.spinner.Tick()); tick != nil {
+		t.Fatal("expected the spinner to stop once the details arrived")
+	}
+	view := page.renderMoviePage()
+	for _, want := range []string{"Paul Atreides se une a Chani.", "Denis Villeneuve", "https://youtu.be/duna"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in the movie page:\n%s", want, view)
+		}
+	}
+
+	updated, _ = page.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if next := updated.(appModel); next.state != stateSelectMovie {
+		t.Fatalf("expected esc to return to the movie list, got %v", next.state)
+	}
+}
+
+func TestSessionPrices_ShowsRealTicketsAndMixTotal(t *testing.T) {
+	app := New().(appModel)
+	app.state = stateShowSessions
+	session := model.TheaterSession{Id: "s1", Price: 40}
+	app.sessionList.SetItems([]list.Item{sessionItem{session: session}})
+	app.sessionPrices["s1"] = sessionPrices{}
+
+	if got := app.sessionList.Items()[0].(sessionItem).Description(); strings.Contains(got, "Half") {
+		t.Fatalf("expected no computed half price before the tickets load, got %q", got)
+	}
+
+	prices := sessionPrices{loaded: true, sections: []finder.SectionPrices{
+		{
+			Section: model.SessionSection{Name: "Sala 1"},
+			Tickets: []model.Ticket{{Name: "Inteira", Total: 46}, {Name: "Meia-Entrada", Total: 23}},
+		},
+		{
+			Section: model.SessionSection{Name: "VIP", LowestPrice: 80, HighestPrice: 90},
+			VIP:     true,
+		},
+	}}
+	updated, _ := app.Update(sessionPricesMsg{gen: app.requestGen, sessionID: "s1", prices: prices})
+	app = updated.(appModel)
+	desc := app.sessionList.Items()[0].(sessionItem).Description()
+	if !strings.Contains(desc, "Full R$ 46.00 • Half R$ 23.00 • VIP R$ 80.00 - R$ 90.00") {
+		t.Fatalf("unexpected description: %q", desc)
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	app = updated.(appModel)
+	app.mixInput = ""
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("2 inteira")},
+		{Type: tea.KeySpace},
+		{Type: tea.KeyRunes, Runes: []rune("+ 1 meia")},
+		{Type: tea.KeyEnter},
+	} {
+		updated, _ = app.Update(key)
+		app = updated.(appModel)
+	}
+	if app.editingMix {
+		t.Fatal("expected enter to apply the mix")
+	}
+	if view := app.renderSessionPrices(60); !strings.Contains(view, "R$ 115.00 (Sala 1)") {
+		t.Fatalf("expected the mix total in the prices panel:\n%s", view)
+	}
+
+	prices.sections = append(prices.sections, finder.SectionPrices{
+		Section: model.SessionSection{Id: "sec2", Name: "Sala 1 D-BOX"},
+		Tickets: []model.Ticket{{Name: "Inteira", Total: 70}, {Name: "Meia-Entrada", Total: 35}},
+	})
+	app.sessionPrices["s1"] = prices
+	app.selectedSession = session
+	app.selectedSection = model.SessionSection{Id: "sec2"}
+	if view := app.renderSessionPrices(60); !strings.Contains(view, "R$ 175.00 (Sala 1 D-BOX)") {
+		t.Fatalf("expected the total of the section opened in the seat map:\n%s", view)
+	}
+
+	prices.sections = append(prices.sections, finder.SectionPrices{
+		Section: model.SessionSection{Id: "sec3", Name: "Sala 1 XD", LowestPrice: 50, HighestPrice: 60},
+		Err:     errors.New("timeout"),
+	})
+	app.sessionPrices["s1"] = prices
+	app.selectedSection = model.SessionSection{Id: "sec3"}
+	view := app.renderSessionPrices(60)
+	if !strings.Contains(view, "Preços parciais: 1 de 4 setores") || !strings.Contains(view, "R$ 115.00 (Sala 1)") {
+		t.Fatalf("expected partial prices and no total for the failed section:\n%s", view)
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	app = updated.(appModel)
+	if view := app.renderSessionPrices(60); strings.Contains(view, "▏") {
+		t.Fatalf("expected the mix prompt only in the header, got the panel:\n%s", view)
+	}
+}
+
+func TestCityGroup_LabelsTheatersAndHidesPerCity(t *testing.T) {
+	ingressotest.IsolateStore(t)
+	group := store.CityGroup{Name: "Grande SP", Cities: []model.City{{Id: "1", Name: "São Paulo"}, {Id: "2", Name: "Guarulhos"}}}
+	if err := store.SaveCityGroup(group); err != nil {
+		t.Fatal(err)
+	}
+
+	app := New().(appModel)
+	updated, _ := app.Update(citiesMsg{gen: app.requestGen, cities: []model.City{{Id: "1", Name: "São Paulo"}}})
+	app = updated.(appModel)
+	if _, ok := app.cityList.Items()[0].(cityGroupItem); !ok {
+		t.Fatalf("expected the group above the cities, got %T", app.cityList.Items()[0])
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(appModel)
+	if app.state != stateLoadingTheaters || app.city.Name != "Grande SP" {
+		t.Fatalf("expected the group's theaters to load, got state %v city %+v", app.state, app.city)
+	}
+
+	theaters := []model.Theater{
+		{Id: "10", Name: "Cinema Paulista", City: "São Paulo", CityId: "1"},
+		{Id: "20", Name: "Cinema Guarulhos", City: "Guarulhos", CityId: "2"},
+	}
+	updated, _ = app.Update(theatersMsg{gen: app.requestGen, theaters: theaters})
+	app = updated.(appModel)
+	for _, item := range app.theaterList.Items() {
+		ti := item.(theaterItem)
+		if !strings.Contains(ti.Description(), ti.theater.City) {
+			t.Fatalf("expected %s to carry its city label, got %q", ti.theater.Name, ti.Description())
+		}
+	}
+
+	app.state = stateManageTheaters
+	for i, item := range app.theaterPref.Items() {
+		if item.(theaterVisibilityItem).theater.Id == "20" {
+			app.theaterPref.Select(i)
+		}
+	}
+	updated, _, _ = app.toggleTheaterVisibility()
+	app = updated.(appModel)
+	if hidden, _ := store.LoadHiddenTheaters("2"); !hidden["20"] {
+		t.Fatalf("expected the theater hidden in its own city, got %+v", hidden)
+	}
+	if hidden, _ := store.LoadHiddenTheaters("1"); hidden["20"] {
+		t.Fatal("expected São Paulo preferences untouched")
+	}
+}
+
+func TestWeek_ShowsTheMovieDayByDay(t *testing.T) {
+	app := New().(appModel)
+	app.state = stateSelectMovie
+	app.city = model.City{Id: "1", Name: "São Paulo"}
+	app.theater = model.Theater{Id: "10", Name: "Cinema A"}
+	app.date = time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	app.movieList.SetItems([]list.Item{movieItem{movie: model.TheaterMovie{Id: "m1", Title: "Duna: Parte 2"}}})
+
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	app = updated.(appModel)
+	if app.state != stateLoadingWeek || cmd == nil {
+		t.Fatalf("expected the week to load, got state %v", app.state)
+	}
+
+	session := model.TheaterSession{Id: "s1"}
+	session.Date.LocalDate = time.Date(2026, 10, 16, 21, 0, 0, 0, time.UTC)
+	week := []finder.DaySessions{
+		{Date: app.date, Sessions: []finder.SessionWithTheater{{Session: session, Theater: app.theater}}},
+		{Date: app.date.AddDate(0, 0, 1)},
+	}
+	updated, _ = app.Update(weekMsg{gen: app.requestGen, week: week})
+	app = updated.(appModel)
+	view := app.renderWeek()
+	for _, want := range []string{"Duna: Parte 2", "Cinema A", "21:00", "sem sessões"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in the week view:\n%s", want, view)
+		}
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app = updated.(appModel)
+	updated, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(appModel)
+	if app.state != stateLoadingSessions || cmd == nil || app.date.Format(time.DateOnly) != "2026-10-17" {
+		t.Fatalf("expected Saturday's movies to load, got state %v date %s", app.state, app.date.Format(time.DateOnly))
+	}
+}
+
+func TestDatePicker_AcrossTheatersUsesTheHighlightedMovie(t *testing.T) {
+	app := New().(appModel)
+	app.state = stateSelectMovie
+	app.browsingAllTheaters = true
+	app.city = model.City{Id: "1", Name: "São Paulo"}
+	app.theaters = []model.Theater{{Id: "10", Name: "Cinema A"}, {Id: "11", Name: "Cinema B"}}
+
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	app = updated.(appModel)
+	if app.state != stateSelectDate || cmd != nil {
+		t.Fatal("expected no per-theater lookup without a highlighted movie")
+	}
+
+	app.state = stateSelectMovie
+	app.movieList.SetItems([]list.Item{movieItem{movie: model.TheaterMovie{Id: "m1", Title: "Duna: Parte 2"}}})
+	updated, cmd = app.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	app = updated.(appModel)
+	if cmd == nil || !strings.Contains(app.dateList.Title, "Duna: Parte 2") {
+		t.Fatalf("expected the movie's days to be looked up, got title %q", app.dateList.Title)
+	}
+}
+
+func TestDatePicker_GreysEmptyDaysAndPicksARange(t *testing.T) {
+	app := New().(appModel)
+	updated, _ := app.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	app = updated.(appModel)
+	app.state = stateSelectMovie
+	app.city = model.City{Id: "1", Name: "São Paulo"}
+	app.theater = model.Theater{Id: "10", Name: "Cinema A"}
+	app.date = time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	app.movieList.SetItems([]list.Item{movieItem{movie: model.TheaterMovie{Id: "m1", Title: "Duna: Parte 2"}}})
+
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	app = updated.(appModel)
+	if app.state != stateSelectDate || cmd == nil {
+		t.Fatalf("expected the date picker to look up available days, got state %v", app.state)
+	}
+	updated, _ = app.Update(availableDaysMsg{gen: app.requestGen, days: map[string]bool{"2026-10-16": true, "2026-10-18": true}})
+	app = updated.(appModel)
+	items := app.dateList.Items()
+	if len(items) != datePickerDays {
+		t.Fatalf("expected %d days, got %d", datePickerDays, len(items))
+	}
+	if items[0].(dateItem).empty || !items[1].(dateItem).empty || items[2].(dateItem).empty {
+		t.Fatalf("expected only Saturday greyed out, got %+v", items[:3])
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	app = updated.(appModel)
+	if !app.dateList.Items()[0].(dateItem).rangeStart {
+		t.Fatal("expected tab to mark the range start")
+	}
+	app.dateList.Select(2)
+	updated, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(appModel)
+	if app.state != stateLoadingWeek || cmd == nil {
+		t.Fatalf("expected the range to open the week view, got state %v", app.state)
+	}
+	if app.date.Format(time.DateOnly) != "2026-10-16" {
+		t.Fatalf("expected the selected date untouched, got %s", app.date.Format(time.DateOnly))
 	}
 }
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/store"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cityGroupItem is a saved group of cities listed above the cities.
+type cityGroupItem struct {
+	group store.CityGroup
+}
+
+func (g cityGroupItem) Title() string {
+	return "👥 " + g.group.Name
+}
+
+func (g cityGroupItem) Description() string {
+	names := make([]string, 0, len(g.group.Cities))
+	for _, city := range g.group.Cities {
+		names = append(names, city.Name)
+	}
+	return fmt.Sprintf("Group • %s", strings.Join(names, ", "))
+}
+
+func (g cityGroupItem) FilterValue() string {
+	return strings.ToLower(g.Description() + " " + g.group.Name)
+}
+
+// buildCityGroupItems lists the saved city groups. Groups that cannot be read
+// are left out so the city list still opens.
+func buildCityGroupItems() []list.Item {
+	groups, _ := store.LoadCityGroups()
+	items := make([]list.Item, 0, len(groups))
+	for _, group := range groups {
+		if len(group.Cities) > 0 {
+			items = append(items, cityGroupItem{group: group})
+		}
+	}
+	return items
+}
+
+func (m appModel) fetchGroupTheatersCmd(group store.CityGroup) tea.Cmd {
+	return func() tea.Msg {
+		ctx, stale := finder.TrackStale(m.requestContext())
+		theaters, failed, err := m.finder.GroupTheaters(ctx, group.Cities)
+		return theatersMsg{gen: m.requestGen, theaters: theaters, failedCities: failed, staleSince: stale.Since(), err: err}
+	}
+}
+
+// openCityGroup loads the theaters of every city of group. m.city only names
+// the group, so requests use the city each theater belongs to.
+func (m appModel) openCityGroup(group store.CityGroup) (tea.Model, tea.Cmd, bool) {
+	m.cityGroup = group
+	m.city = model.City{Name: group.Name}
+	m.theater = model.Theater{}
+	m.browsingAllTheaters = false
+	m.beginRequests()
+	m.state = stateLoadingTheaters
+	return m, tea.Batch(m.fetchGroupTheatersCmd(group), m.spinner.Tick), true
+}
+
+// cityIDs returns the cities being browsed: the group's or the single city.
+func (m appModel) cityIDs() []string {
+	if len(m.cityGroup.Cities) > 0 {
+		return m.cityGroup.CityIDs()
+	}
+	return []string{m.city.Id}
+}
+
+// theaterCityID returns the city requests about theater must use.
+func (m appModel) theaterCityID(theater model.Theater) string {
+	return finder.TheaterCityID(theater, m.city.Id)
+}
+
+// theaterCityLabel names a theater with its city, for lists mixing cities.
+func theaterCityLabel(theater model.Theater) string {
+	if theater.City == "" {
+		return theater.Name
+	}
+	return fmt.Sprintf("%s (%s)", theater.Name, theater.City)
+}
@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"strings"
+
+	"ingresso-finder-cli/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// moviePageMaxWidth keeps the synopsis readable on wide terminals.
+const moviePageMaxWidth = 100
+
+type eventMsg struct {
+	gen   int
+	event model.Event
+	err   error
+}
+
+func (m appModel) fetchEventCmd(eventID string) tea.Cmd {
+	return func() tea.Msg {
+		event, err := m.finder.Event(m.requestContext(), eventID)
+		return eventMsg{gen: m.requestGen, event: event, err: err}
+	}
+}
+
+// openMoviePage shows Ingresso's details of the selected movie full screen.
+func (m appModel) openMoviePage() (tea.Model, tea.Cmd, bool) {
+	item, ok := m.movieList.SelectedItem().(movieItem)
+	if !ok {
+		return m, nil, true
+	}
+	m.beginRequests()
+	m.movieEvent = nil
+	m.movieEventErr = nil
+	m.state = stateShowMovie
+	if item.movie.Id == "" {
+		return m, nil, true
+	}
+	return m, tea.Batch(m.fetchEventCmd(item.movie.Id), m.spinner.Tick), true
+}
+
+// loadingMovieEvent reports whether the movie page is still waiting for
+// Ingresso's details, so the spinner keeps turning.
+func (m appModel) loadingMovieEvent() bool {
+	if m.state != stateShowMovie || m.movieEvent != nil || m.movieEventErr != nil {
+		return false
+	}
+	item, ok := m.movieList.SelectedItem().(movieItem)
+	return ok && item.movie.Id != ""
+}
+
+// eventPosterURL returns the portrait poster of event, if it has one.
+func eventPosterURL(event model.Event) string {
+	for _, image := range event.Images {
+		if image.Type == "PosterPortrait" && image.Url != "" {
+			return image.Url
+		}
+	}
+	return ""
+}
+
+// eventTrailerURL returns the first trailer link of event.
+func eventTrailerURL(event model.Event) string {
+	for _, trailer := range event.Trailers {
+		if trailer.Url != "" {
+			return trailer.Url
+		}
+	}
+	return ""
+}
+
+func (m appModel) renderMoviePage() string {
+	item, ok := m.movieList.SelectedItem().(movieItem)
+	if !ok {
+		return ""
+	}
+	movie := item.movie
+	width := moviePageMaxWidth
+	if m.width > 0 {
+		width = min(m.width-4, moviePageMaxWidth)
+	}
+	poster, hasPoster := m.posters[movie.Title]
+	if hasPoster && width >= posterCols+2+minDetailWidth {
+		width -= posterCols + 2
+	} else {
+		hasPoster = false
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Width(15)
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Width(max(width-15, 10))
+	row := func(label, value string) string {
+		if strings.TrimSpace(value) == "" {
+			return ""
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(label), valueStyle.Render(value)) + "\n"
+	}
+
+	content := titleStyle.Render("🎬 "+movie.Title) + "\n\n"
+	event := model.Event{Title: movie.Title, OriginalTitle: movie.OriginalTitle, ContentRating: movie.ContentRating, Duration: movie.Duration}
+	if m.movieEvent != nil {
+		event = *m.movieEvent
+	}
+	if !strings.EqualFold(event.OriginalTitle, movie.Title) {
+		content += row("Original:", event.OriginalTitle)
+	}
+	content += row("Classificação:", event.ContentRating)
+	if event.Duration != "" {
+		content += row("Duração:", event.Duration+" min")
+	}
+	content += row("Gênero:", strings.Join(event.Genres, ", "))
+	if !event.PremiereDate.LocalDate.IsZero() {
+		content += row("Estreia:", event.PremiereDate.LocalDate.Format("02/01/2006"))
+	}
+	content += row("Direção:", event.Director)
+	content += row("Elenco:", event.Cast)
+	content += row("Distribuidora:", event.Distributor)
+	content += row("País:", event.CountryOrigin)
+
+	switch {
+	case m.movieEventErr != nil:
+		content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Render("Não foi possível carregar a ficha da Ingresso: "+m.movieEventErr.Error()) + "\n"
+	case movie.Id == "":
+		content += "\n" + hint("A Ingresso não informou o id deste filme.") + "\n"
+	case m.movieEvent == nil:
+		content += "\n" + m.spinner.View() + " Carregando ficha do filme..." + "\n"
+	}
+
+	if event.Synopsis != "" {
+		content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("250")).Width(width).Render(event.Synopsis) + "\n"
+	}
+
+	var links []string
+	for _, trailer := range event.Trailers {
+		if trailer.Url != "" {
+			links = append(links, trailer.Url)
+		}
+	}
+	if len(links) > 0 {
+		content += "\n" + row("Trailers:", strings.Join(links, "\n"))
+	}
+	content += row("Página:", event.SiteURL)
+
+	if hasPoster {
+		content = lipgloss.JoinHorizontal(lipgloss.Top, poster, "  ", content)
+	}
+	return lipgloss.NewStyle().Padding(0, 2).Render(content)
+}
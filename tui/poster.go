@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ingresso-finder-cli/service"
+	"ingresso-finder-cli/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi/kitty"
+	"github.com/muesli/termenv"
+)
+
+// Poster size in terminal cells. Cells are about twice as tall as wide, so
+// 16x12 cells keep the 2:3 aspect of a movie poster.
+const (
+	posterCols = 16
+	posterRows = 12
+	// minDetailWidth is the room the movie facts need beside the poster.
+	minDetailWidth = 30
+)
+
+// posterMode is how posters are drawn. Sixel is not supported: a sixel image
+// is painted once at the cursor, and Bubble Tea's line-based redraws would
+// leave it misplaced or erased, so sixel-only terminals get half-block art.
+type posterMode int
+
+const (
+	posterOff posterMode = iota
+	// posterBlocks draws two pixels per cell with "▀" and fg/bg colors.
+	posterBlocks
+	// posterKitty uses the kitty graphics protocol with Unicode placeholders,
+	// which survive Bubble Tea redraws like ordinary text.
+	posterKitty
+)
+
+// detectPosterMode reads INGRESSO_POSTERS (auto, kitty, blocks or off). In
+// auto mode kitty and Ghostty get real images, other color terminals get
+// half-block art and everything else stays text only. Kitty loads posters
+// from the cache directory, so it is not used over SSH.
+func detectPosterMode() posterMode {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("INGRESSO_POSTERS"))) {
+	case "off", "none", "text":
+		return posterOff
+	case "blocks":
+		return posterBlocks
+	case "kitty":
+		return posterKitty
+	}
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		return posterOff
+	}
+	remote := os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != ""
+	kittyTerm := os.Getenv("TERM") == "xterm-kitty" || os.Getenv("KITTY_WINDOW_ID") != "" ||
+		strings.EqualFold(os.Getenv("TERM_PROGRAM"), "ghostty")
+	if kittyTerm && !remote {
+		return posterKitty
+	}
+	return posterBlocks
+}
+
+type posterMsg struct {
+	title string
+	url   string
+	art   string
+	err   error
+}
+
+// fetchPosterCmd downloads the poster at url, or reads it from the cache, and
+// renders it for the current poster mode. It returns nil when posters are off
+// or url already failed.
+func (m appModel) fetchPosterCmd(title, url string) tea.Cmd {
+	mode := m.posterMode
+	if mode == posterOff || url == "" || m.failedPosters[url] {
+		return nil
+	}
+	ctx := m.requestContext()
+	return func() tea.Msg {
+		data, ok := store.LoadPoster(url)
+		if ok {
+			service.Emit(service.RequestEvent{Source: service.SourcePoster, URL: url, Cache: service.CacheStore})
+		} else {
+			var err error
+			if data, err = service.FetchPoster(ctx, nil, url); err != nil {
+				return posterMsg{title: title, url: url, err: err}
+			}
+			_ = store.SavePoster(url, data)
+		}
+
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return posterMsg{title: title, url: url, err: fmt.Errorf("decode poster: %w", err)}
+		}
+		var art string
+		if mode == posterKitty {
+			art, err = kittyPoster(url, img)
+		} else {
+			art = halfBlockPoster(img, posterCols, posterRows)
+		}
+		return posterMsg{title: title, url: url, art: art, err: err}
+	}
+}
+
+// halfBlockPoster scales img to cols x rows*2 pixels and draws each pair of
+// vertical pixels as one "▀" cell.
+func halfBlockPoster(img image.Image, cols, rows int) string {
+	var b strings.Builder
+	for row := range rows {
+		for col := range cols {
+			top := averageColor(img, col, row*2, cols, rows*2)
+			bottom := averageColor(img, col, row*2+1, cols, rows*2)
+			b.WriteString(lipgloss.NewStyle().Foreground(top).Background(bottom).Render("▀"))
+		}
+		if row < rows-1 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// averageColor averages the block of img that lands on pixel (x, y) of a
+// w x h scaled copy.
+func averageColor(img image.Image, x, y, w, h int) lipgloss.Color {
+	bounds := img.Bounds()
+	x0 := bounds.Min.X + x*bounds.Dx()/w
+	x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/w, x0+1)
+	y0 := bounds.Min.Y + y*bounds.Dy()/h
+	y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/h, y0+1)
+	var r, g, bl, n uint64
+	for py := y0; py < y1; py++ {
+		for px := x0; px < x1; px++ {
+			c := color.RGBAModel.Convert(img.At(px, py)).(color.RGBA)
+			r, g, bl, n = r+uint64(c.R), g+uint64(c.G), bl+uint64(c.B), n+1
+		}
+	}
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r/n, g/n, bl/n))
+}
+
+// kittyPoster saves img as PNG next to the cached poster and returns the
+// escape that makes kitty load it as a virtual placement, followed by the
+// placeholder cells that show it. The terminal reads the file itself, so the
+// escape stays small enough to resend whenever Bubble Tea redraws the line.
+func kittyPoster(url string, img image.Image) (string, error) {
+	path, err := store.PosterPath(url, "png")
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return "", fmt.Errorf("encode poster: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return "", err
+		}
+	}
+
+	// Placeholders name their image through the 24-bit foreground color
+	// and a third diacritic holding the most significant byte, so the id can
+	// use all 32 bits of the hash and posters do not replace each other.
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(url))
+	id := hash.Sum32()
+	if id == 0 {
+		id = 1
+	}
+
+	var b strings.Builder
+	err = kitty.EncodeGraphics(&b, nil, &kitty.Options{
+		Action:           kitty.TransmitAndPut,
+		Transmission:     kitty.File,
+		File:             path,
+		Format:           kitty.PNG,
+		ID:               int(id),
+		VirtualPlacement: true,
+		Columns:          posterCols,
+		Rows:             posterRows,
+		Quite:            2,
+	})
+	if err != nil {
+		return "", err
+	}
+	for row := range posterRows {
+		fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%dm", id>>16&0xff, id>>8&0xff, id&0xff)
+		for col := range posterCols {
+			b.WriteRune(kitty.Placeholder)
+			b.WriteRune(kitty.Diacritic(row))
+			b.WriteRune(kitty.Diacritic(col))
+			b.WriteRune(kitty.Diacritic(int(id >> 24)))
+		}
+		b.WriteString("\x1b[39m")
+		if row < posterRows-1 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String(), nil
+}
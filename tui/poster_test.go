@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/store"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func testPoster() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 96))
+	for y := range 96 {
+		for x := range 64 {
+			img.Set(x, y, color.RGBA{R: uint8(x * 4), G: uint8(y * 2), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func TestHalfBlockPoster_FillsTheCellGrid(t *testing.T) {
+	art := halfBlockPoster(testPoster(), posterCols, posterRows)
+	lines := strings.Split(art, "\n")
+	if len(lines) != posterRows {
+		t.Fatalf("expected %d rows, got %d", posterRows, len(lines))
+	}
+	for i, line := range lines {
+		if width := lipgloss.Width(line); width != posterCols {
+			t.Fatalf("row %d is %d cells wide, want %d", i, width, posterCols)
+		}
+	}
+}
+
+func TestKittyPoster_ReservesTheCellGrid(t *testing.T) {
+	store.SetCacheDir(t.TempDir())
+	t.Cleanup(func() { store.SetCacheDir("") })
+
+	art, err := kittyPoster("https://image.tmdb.org/t/p/w342/poster.jpg", testPoster())
+	if err != nil {
+		t.Fatalf("kittyPoster() error = %v", err)
+	}
+	if !strings.HasPrefix(art, "\x1b_G") || !strings.Contains(art, "U=1") {
+		t.Fatalf("expected a virtual placement escape, got %q", art[:min(len(art), 80)])
+	}
+	lines := strings.Split(art, "\n")
+	if len(lines) != posterRows {
+		t.Fatalf("expected %d rows, got %d", posterRows, len(lines))
+	}
+	for i, line := range lines {
+		if width := lipgloss.Width(line); width != posterCols {
+			t.Fatalf("row %d is %d cells wide, want %d", i, width, posterCols)
+		}
+	}
+
+	other, err := kittyPoster("https://image.tmdb.org/t/p/w342/other.jpg", testPoster())
+	if err != nil {
+		t.Fatalf("kittyPoster() error = %v", err)
+	}
+	if placeholderColor(other) == placeholderColor(art) {
+		t.Fatalf("expected posters to get their own image ids, both use %q", placeholderColor(art))
+	}
+}
+
+// placeholderColor returns the foreground escape naming a poster's image.
+func placeholderColor(art string) string {
+	start := strings.Index(art, "\x1b[38;2;")
+	if start < 0 {
+		return ""
+	}
+	end := strings.IndexByte(art[start:], 'm')
+	return art[start : start+end+1]
+}
+
+func TestRenderMovieDetail_ShowsPosterBesideDetails(t *testing.T) {
+	item := movieItem{movie: model.TheaterMovie{Title: "Nosferatu"}}
+	m := appModel{posters: map[string]string{"Nosferatu": halfBlockPoster(testPoster(), posterCols, posterRows)}}
+	m.movieList = list.New([]list.Item{item}, list.NewDefaultDelegate(), 10, 10)
+	m.movieList.Select(0)
+
+	if result := m.renderMovieDetail(80); !strings.Contains(result, "▀") || !strings.Contains(result, "Nosferatu") {
+		t.Fatalf("expected the poster next to the details, got:\n%s", result)
+	}
+	if result := m.renderMovieDetail(posterCols + minDetailWidth); strings.Contains(result, "▀") {
+		t.Fatalf("expected no poster in a narrow panel, got:\n%s", result)
+	}
+}
+
+func TestPosterMsg_RemembersFailedDownloads(t *testing.T) {
+	m := New().(appModel)
+	m.posterMode = posterBlocks
+	const url = "https://example.test/nosferatu.jpg"
+
+	updated, _ := m.Update(posterMsg{title: "Nosferatu", url: url, err: errors.New("status 404")})
+	m = updated.(appModel)
+	if cmd := m.fetchPosterCmd("Nosferatu", url); cmd != nil {
+		t.Fatal("expected a failed poster not to be requested again")
+	}
+
+	updated, _ = m.Update(posterMsg{title: "Nosferatu", url: "https://example.test/other.jpg", err: context.Canceled})
+	m = updated.(appModel)
+	if cmd := m.fetchPosterCmd("Nosferatu", "https://example.test/other.jpg"); cmd == nil {
+		t.Fatal("expected a canceled poster to be requested again")
+	}
+}
@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"ingresso-finder-cli/finder"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultTicketMixText is the ticket mix totaled when INGRESSO_TICKET_MIX is
+// unset or invalid.
+const defaultTicketMixText = "1 inteira"
+
+// sessionPrices holds the sections and tickets of one session.
+type sessionPrices struct {
+	sections []finder.SectionPrices
+	loaded   bool
+	err      error
+}
+
+type sessionPricesMsg struct {
+	gen       int
+	sessionID string
+	prices    sessionPrices
+}
+
+// defaultTicketMix reads INGRESSO_TICKET_MIX, like "2 inteira + 1 meia".
+func defaultTicketMix() []finder.TicketCount {
+	if raw := strings.TrimSpace(os.Getenv("INGRESSO_TICKET_MIX")); raw != "" {
+		if mix, err := finder.ParseTicketMix(raw); err == nil {
+			return mix
+		}
+	}
+	mix, _ := finder.ParseTicketMix(defaultTicketMixText)
+	return mix
+}
+
+func (m appModel) fetchSessionPricesCmd(sessionID string) tea.Cmd {
+	return func() tea.Msg {
+		sections, err := m.finder.SessionPrices(m.requestContext(), sessionID)
+		return sessionPricesMsg{gen: m.requestGen, sessionID: sessionID, prices: sessionPrices{sections: sections, loaded: true, err: err}}
+	}
+}
+
+// pendingPriceSessionIDsOnCurrentPage returns the sessions on the visible
+// page whose prices were not requested yet.
+func (m appModel) pendingPriceSessionIDsOnCurrentPage() []string {
+	var ids []string
+	for _, si := range m.sessionsOnCurrentPage() {
+		if si.session.Id == "" {
+			continue
+		}
+		if _, alreadyRequested := m.sessionPrices[si.session.Id]; alreadyRequested {
+			continue
+		}
+		ids = append(ids, si.session.Id)
+	}
+	return ids
+}
+
+func (m *appModel) updateSessionPrices(sessionID string, prices sessionPrices) tea.Cmd {
+	for i, item := range m.sessionList.Items() {
+		si, ok := item.(sessionItem)
+		if ok && si.session.Id == sessionID {
+			si.prices = prices
+			return m.sessionList.SetItem(i, si)
+		}
+	}
+	return nil
+}
+
+// priceLabel describes the regular and VIP prices of a session for the
+// session list. Until the checkout prices arrive it shows the session price.
+func (s sessionItem) priceLabel() string {
+	if !s.prices.loaded || s.prices.err != nil {
+		return "Full " + formatPrice(s.session.Price)
+	}
+	var parts []string
+	regular := ""
+	for _, section := range s.prices.sections {
+		if section.VIP {
+			continue
+		}
+		full, hasFull := section.Ticket("inteira")
+		half, hasHalf := section.Ticket("meia")
+		if hasFull && hasHalf {
+			regular = fmt.Sprintf("Full %s • Half %s", formatPrice(finder.TicketPrice(full)), formatPrice(finder.TicketPrice(half)))
+			break
+		}
+	}
+	if regular == "" {
+		if low, high, ok := finder.PriceRange(s.prices.sections, false); ok {
+			regular = formatPriceRange(low, high)
+		} else if s.session.Price > 0 {
+			regular = "Full " + formatPrice(s.session.Price)
+		}
+	}
+	if regular != "" {
+		parts = append(parts, regular)
+	}
+	if low, high, ok := finder.PriceRange(s.prices.sections, true); ok {
+		parts = append(parts, "VIP "+formatPriceRange(low, high))
+	}
+	if len(parts) == 0 {
+		return "Full " + formatPrice(s.session.Price)
+	}
+	return strings.Join(parts, " • ")
+}
+
+func formatPriceRange(low, high float64) string {
+	if low == high {
+		return formatPrice(low)
+	}
+	return fmt.Sprintf("%s - %s", formatPrice(low), formatPrice(high))
+}
+
+// startTicketMixEdit opens the ticket mix prompt with the current mix.
+func (m appModel) startTicketMixEdit() (tea.Model, tea.Cmd, bool) {
+	m.editingMix = true
+	m.mixInput = finder.FormatTicketMix(m.ticketMix)
+	return m, nil, true
+}
+
+// handleTicketMixInput edits the ticket mix prompt. Enter applies the mix and
+// esc discards the edit.
+func (m *appModel) handleTicketMixInput(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		mix, err := finder.ParseTicketMix(m.mixInput)
+		if err != nil {
+			m.notice = fmt.Sprintf("⚠️  Combinação inválida: %v", err)
+			return
+		}
+		m.ticketMix = mix
+		m.editingMix = false
+	case tea.KeyEsc:
+		m.editingMix = false
+	case tea.KeyBackspace:
+		if runes := []rune(m.mixInput); len(runes) > 0 {
+			m.mixInput = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.mixInput += " "
+	case tea.KeyRunes:
+		m.mixInput += string(msg.Runes)
+	}
+}
+
+// mixSection picks the section the ticket mix is totaled for: the one last
+// opened in the seat map for this session, else the first with tickets.
+// Sections whose tickets failed to load have none, so they are never picked.
+func (m appModel) mixSection(sessionID string, sections []finder.SectionPrices) *finder.SectionPrices {
+	var first *finder.SectionPrices
+	for i, section := range sections {
+		if len(section.Tickets) == 0 {
+			continue
+		}
+		if m.selectedSession.Id == sessionID && section.Section.Id != "" && section.Section.Id == m.selectedSection.Id {
+			return &sections[i]
+		}
+		if first == nil {
+			first = &sections[i]
+		}
+	}
+	return first
+}
+
+// renderSessionPrices lists the ticket categories of each section of the
+// highlighted session and totals the ticket mix for the marked section.
+func (m appModel) renderSessionPrices(maxWidth int) string {
+	item, ok := m.sessionList.SelectedItem().(sessionItem)
+	if !ok {
+		return ""
+	}
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Width(min(24, max(maxWidth-12, 10)))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	vipStyle := lipgloss.NewStyle().Background(lipgloss.Color("136")).Foreground(lipgloss.Color("0")).Bold(true).Padding(0, 1)
+
+	content := titleStyle.Render("🎟️  Ingressos") + "\n"
+	prices := m.sessionPrices[item.session.Id]
+	switch {
+	case !prices.loaded:
+		return content + hint("Carregando preços...") + "\n\n"
+	case prices.err != nil || len(prices.sections) == 0:
+		return content + hint("Preços indisponíveis para esta sessão.") + "\n\n"
+	}
+
+	mixSection := m.mixSection(item.session.Id, prices.sections)
+	failed := 0
+	for i, section := range prices.sections {
+		name := section.Section.Name
+		if section.VIP {
+			name += " " + vipStyle.Render("VIP")
+		}
+		if mixSection == &prices.sections[i] {
+			name = "› " + name
+		}
+		content += "\n" + valueStyle.Render(name) + "\n"
+		if len(section.Tickets) == 0 {
+			low, high := section.Range()
+			content += lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("  Faixa"), valueStyle.Render(formatPriceRange(low, high))) + "\n"
+			if section.Err != nil {
+				failed++
+				content += hint("  Ingressos não carregaram.") + "\n"
+			}
+			continue
+		}
+		for _, ticket := range section.Tickets {
+			content += lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("  "+ticket.Name), valueStyle.Render(formatPrice(finder.TicketPrice(ticket)))) + "\n"
+		}
+	}
+
+	if failed > 0 {
+		content += "\n" + hint(fmt.Sprintf("⚠️  Preços parciais: %d de %d setores não carregaram.", failed, len(prices.sections))) + "\n"
+	}
+
+	// The prompt editing the mix is in the header; the panel keeps the
+	// applied mix and its total.
+	content += "\n" + lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Combinação:"), valueStyle.Render(finder.FormatTicketMix(m.ticketMix))) + "\n"
+	if mixSection != nil {
+		if total, err := finder.MixTotal(*mixSection, m.ticketMix); err != nil {
+			content += hint(err.Error()) + "\n"
+		} else {
+			content += lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Total:"), valueStyle.Bold(true).Render(fmt.Sprintf("%s (%s)", formatPrice(total), mixSection.Section.Name))) + "\n"
+		}
+	}
+	return content + "\n"
+}
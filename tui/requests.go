@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// requestLogRefresh is how often the open request inspector redraws.
+const requestLogRefresh = time.Second
+
+// toggleRequestLog opens or closes the request inspector. Every opening gets a
+// new generation so refresh ticks from an earlier one stop.
+func (m appModel) toggleRequestLog() (tea.Model, tea.Cmd, bool) {
+	m.showRequests = !m.showRequests
+	if !m.showRequests {
+		return m, nil, true
+	}
+	m.requestLogGen++
+	return m, requestLogTickCmd(m.requestLogGen), true
+}
+
+// handleRequestLogKey handles keys while the inspector covers the screen, so
+// they do not reach the lists underneath.
+func (m appModel) handleRequestLogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit, true
+	case "esc", "ctrl+g":
+		return m.toggleRequestLog()
+	}
+	return m, nil, true
+}
+
+func requestLogTickCmd(gen int) tea.Cmd {
+	return tea.Tick(requestLogRefresh, func(time.Time) tea.Msg {
+		return requestLogTickMsg{gen: gen}
+	})
+}
+
+// renderRequestLog lists the latest requests and cache answers, newest last,
+// under a summary of everything recorded so far.
+func (m appModel) renderRequestLog() string {
+	events := service.RecentEvents()
+	var requests, cached, failed int
+	var latency time.Duration
+	for _, event := range events {
+		switch {
+		case event.Cache != "":
+			cached++
+		default:
+			requests++
+			latency += event.Latency
+		}
+		if event.Err != "" || event.Status >= 400 {
+			failed++
+		}
+	}
+	summary := fmt.Sprintf("🔎 %d requisições • %d do cache • %d falhas", requests, cached, failed)
+	if requests > 0 {
+		summary += fmt.Sprintf(" • latência média %s", (latency / time.Duration(requests)).Round(time.Millisecond))
+	}
+
+	rows := max(m.height-8, 5)
+	if len(events) > rows {
+		events = events[len(events)-rows:]
+	}
+	width := max(m.width, 80)
+
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	cacheStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(summary), ""}
+	if len(events) == 0 {
+		lines = append(lines, hint("Nenhuma requisição ainda."))
+	}
+	for _, event := range events {
+		result := fmt.Sprintf("%3d", event.Status)
+		switch {
+		case event.Cache != "":
+			result = cacheStyle.Render(event.Cache)
+		case event.Status == 0:
+			result = errStyle.Render("err")
+		case event.Status >= 400:
+			result = errStyle.Render(result)
+		}
+		latency := "-"
+		if event.Latency > 0 {
+			latency = event.Latency.Round(time.Millisecond).String()
+		}
+		// result may be styled, so it is padded by its visible width.
+		result += strings.Repeat(" ", max(11-lipgloss.Width(result), 0))
+		line := fmt.Sprintf("%s %-8s %s #%d %7s %8s  ",
+			event.Time.Format("15:04:05"),
+			event.Source,
+			result,
+			max(event.Attempt, 1),
+			latency,
+			formatBytes(event.Bytes),
+		)
+		url := event.URL
+		if room := width - lipgloss.Width(line) - 2; room > 0 {
+			url = ansi.Truncate(url, room, "…")
+		}
+		lines = append(lines, line+url)
+	}
+	lines = append(lines, "", hint("ctrl+g ou esc fecha"))
+	return strings.Join(lines, "\n")
+}
+
+func formatBytes(n int) string {
+	switch {
+	case n <= 0:
+		return "-"
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	default:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	}
+}
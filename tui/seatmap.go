@@ -0,0 +1,7 @@
+package tui
+
+import "ingresso-finder-cli/render"
+
+func (m appModel) renderSeatMap() string {
+	return render.SeatMap(m.seatMap, render.SeatMapOptions{ShowNumbers: m.showSeatNumbers})
+}
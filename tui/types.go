@@ -1,8 +1,10 @@
 package tui
 
 import (
+	"context"
 	"time"
 
+	"ingresso-finder-cli/finder"
 	"ingresso-finder-cli/model"
 	"ingresso-finder-cli/service"
 	"ingresso-finder-cli/store"
@@ -11,6 +13,17 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 )
 
+// Seat map watch defaults: how often the open seat map is polled and the
+// block sizes the +/- keys cycle through.
+const (
+	seatWatchInterval    = 30 * time.Second
+	defaultSeatWatchSize = 2
+	maxSeatWatchSize     = 10
+)
+
+// detailCastSize is how many actors the movie detail panel lists.
+const detailCastSize = 3
+
 type appState int
 
 const (
@@ -26,11 +39,16 @@ const (
 	stateSelectSection
 	stateShowSeatMap
 	stateManageTheaters
+	stateShowMovie
+	stateLoadingWeek
+	stateShowWeek
 	stateError
 )
 
 type appModel struct {
-	client *service.Client
+	client   *service.Client
+	metadata service.MetadataProvider
+	finder   *finder.Finder
 
 	state     appState
 	lastState appState
@@ -43,12 +61,19 @@ type appModel struct {
 	theaters []model.Theater
 	days     []model.TheaterSessionDay
 
-	city    model.City
-	theater model.Theater
-	date    time.Time
+	city model.City
+	// cityGroup is the group of cities being browsed, if any; m.city then
+	// only carries the group name.
+	cityGroup store.CityGroup
+	theater   model.Theater
+	date      time.Time
 
 	dateReturnState    appState
 	dateReturnStateSet bool
+	// availableDays holds the days with sessions offered by the date picker,
+	// nil until known; rangeStart is the first day of a range being picked.
+	availableDays map[string]bool
+	rangeStart    time.Time
 
 	cityList    list.Model
 	theaterList list.Model
@@ -63,16 +88,72 @@ type appModel struct {
 	selectedSection model.SessionSection
 	showSeatNumbers bool
 
+	seatWatching     bool
+	seatWatchSize    int
+	seatWatchGen     int
+	seatWatchChecked time.Time
+	// seatWatchLabels are the seats the watch waits for instead of a block of
+	// seatWatchSize; seatLabelInput holds the prompt text while
+	// editingSeatLabels.
+	seatWatchLabels   []string
+	editingSeatLabels bool
+	seatLabelInput    string
+	// seatAlert stays in the header until ctrl+x dismisses it.
+	seatAlert string
+
 	spinner spinner.Model
 
-	seatCounts   map[string]seatCount
-	movieRatings map[string]store.OMDbRating
+	seatCounts    map[string]seatCount
+	sessionPrices map[string]sessionPrices
+	movieMetadata map[string]store.MovieMetadata
+	// posters holds the rendered poster of each movie title.
+	posters map[string]string
+	// failedPosters holds the poster URLs that could not be downloaded or
+	// decoded, so they are not requested again.
+	failedPosters map[string]bool
+	posterMode    posterMode
+
+	// movieEvent is Ingresso's details of the movie on the movie page, nil
+	// while loading.
+	movieEvent    *model.Event
+	movieEventErr error
+
+	// ticketMix is totaled with the prices of the highlighted session;
+	// mixInput holds the prompt text while editingMix.
+	ticketMix  []finder.TicketCount
+	editingMix bool
+	mixInput   string
+
+	// week is the movie's sessions day by day in the week view and weekCursor
+	// the highlighted day.
+	week       []finder.DaySessions
+	weekMovie  model.TheaterMovie
+	weekCursor int
+	weekFailed int
 
 	hiddenTheaters      map[string]bool
 	userLocation        *service.UserLocation
 	browsingAllTheaters bool
 
 	errorSuggestNextDay bool
+
+	notice string
+
+	// requestCtx is canceled when the user leaves the screen that started the
+	// requests made with it; requestGen tags their messages so late answers
+	// from a previous screen are dropped.
+	requestCtx     context.Context
+	cancelRequests context.CancelFunc
+	requestGen     int
+
+	showRequests  bool
+	requestLogGen int
+
+	// staleSince is when the cached data on screen was saved, set while the
+	// finder answers from stale caches because the network failed.
+	staleSince time.Time
+	// now is the clock the header reads, replaced in tests.
+	now func() time.Time
 }
 
 type errMsg struct {
@@ -82,56 +163,83 @@ type errMsg struct {
 	suggestNextDay bool
 }
 
+type noticeMsg struct {
+	text string
+}
+
 type citiesMsg struct {
-	cities []model.City
-	err    error
+	gen        int
+	cities     []model.City
+	staleSince time.Time
+	err        error
 }
 
 type cityMsg struct {
+	gen  int
 	city model.City
 	err  error
 }
 
 type theatersMsg struct {
+	gen      int
 	theaters []model.Theater
-	err      error
+	// failedCities counts the cities of a group whose theaters could not be listed.
+	failedCities int
+	staleSince   time.Time
+	err          error
 }
 
 type sessionsMsg struct {
-	days []model.TheaterSessionDay
-	err  error
+	gen        int
+	days       []model.TheaterSessionDay
+	staleSince time.Time
+	err        error
 }
 
 type seatCountMsg struct {
+	gen       int
 	sessionID string
 	count     seatCount
 }
 
 type sessionDetailsMsg struct {
+	gen    int
 	detail model.SessionDetail
 	err    error
 }
 
 type seatMapMsg struct {
+	gen     int
+	seatMap model.SeatMap
+	err     error
+}
+
+type requestLogTickMsg struct {
+	gen int
+}
+
+type seatWatchTickMsg struct {
+	gen int
+}
+
+type seatWatchMsg struct {
+	gen     int
 	seatMap model.SeatMap
 	err     error
 }
 
 type movieCatalogMsg struct {
-	movies     []movieAggregate
+	gen        int
+	movies     []finder.MovieAggregate
 	err        error
 	failed     int
 	ignored    int
 	noSessions bool
+	staleSince time.Time
 }
 
 type locationMsg struct {
+	gen      int
 	location service.UserLocation
 	err      error
 }
-
-type theaterSessionsResult struct {
-	theater model.Theater
-	days    []model.TheaterSessionDay
-	err     error
-}
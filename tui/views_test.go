@@ -3,12 +3,17 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"ingresso-finder-cli/finder"
 	"ingresso-finder-cli/model"
+	"ingresso-finder-cli/service"
 
 	"ingresso-finder-cli/store"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestRenderMovieDetail_Empty(t *testing.T) {
@@ -109,10 +114,10 @@ func TestRenderMovieDetail_GlobalSessions(t *testing.T) {
 
 	item := movieItem{
 		movie: movie,
-		globalSessions: []sessionWithTheater{
-			{theater: model.Theater{Name: "Cinema 1"}},
-			{theater: model.Theater{Name: "Cinema 2"}},
-			{theater: model.Theater{Name: "Cinema 1"}}, // Deduplication check
+		globalSessions: []finder.SessionWithTheater{
+			{Theater: model.Theater{Name: "Cinema 1"}},
+			{Theater: model.Theater{Name: "Cinema 2"}},
+			{Theater: model.Theater{Name: "Cinema 1"}}, // Deduplication check
 		},
 	}
 
@@ -137,7 +142,7 @@ func TestRenderMovieDetail_WithRatingsAndPlot(t *testing.T) {
 	}
 
 	m := appModel{
-		movieRatings: map[string]store.OMDbRating{
+		movieMetadata: map[string]store.MovieMetadata{
 			"The Matrix": {
 				ImdbRating: "8.7",
 				Rotten:     "83%",
@@ -182,7 +187,7 @@ func TestRenderMovieDetail_NotFoundInOMDb(t *testing.T) {
 	}
 
 	m := appModel{
-		movieRatings: map[string]store.OMDbRating{
+		movieMetadata: map[string]store.MovieMetadata{
 			"Filme Obscuro Independente": {
 				NotFound: true,
 			},
@@ -194,7 +199,51 @@ func TestRenderMovieDetail_NotFoundInOMDb(t *testing.T) {
 
 	result := m.renderMovieDetail(60)
 
-	if !strings.Contains(result, "Filme não encontrado no IMDb") {
+	if !strings.Contains(result, "Filme não encontrado nas bases de filmes") {
 		t.Errorf("expected 'Not Found' message for uncataloged movies, got:\n%s", result)
 	}
 }
+
+func TestHeaderView_OfflineBadge(t *testing.T) {
+	m := New().(appModel)
+	if strings.Contains(m.headerView(), "offline") {
+		t.Fatal("expected no offline badge while the network answers")
+	}
+
+	m.now = func() time.Time { return time.Date(2026, 10, 15, 0, 0, 30, 0, time.Local) }
+	m.staleSince = time.Date(2026, 10, 14, 23, 59, 0, 0, time.Local)
+	if want := "offline, dados de 14/10 23:59"; !strings.Contains(m.headerView(), want) {
+		t.Fatalf("expected %q in header:\n%s", want, m.headerView())
+	}
+	m.staleSince = time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)
+	if want := "offline, dados das 00:00"; !strings.Contains(m.headerView(), want) {
+		t.Fatalf("expected %q in header:\n%s", want, m.headerView())
+	}
+
+	lastWeek := time.Date(2026, 10, 8, 14, 32, 0, 0, time.Local)
+	if got := offlineLabel(lastWeek, lastWeek.AddDate(0, 0, 7)); got != "📴 offline, dados de 08/10 14:32" {
+		t.Fatalf("unexpected label %q", got)
+	}
+}
+
+func TestRenderRequestLog_AlignsStyledResultsAndCutsByWidth(t *testing.T) {
+	url := "https://ingresso.test/ação/" + strings.Repeat("ação-", 20)
+	service.Emit(service.RequestEvent{Source: service.SourceIngresso, URL: url, Status: 200, Attempt: 1})
+	service.Emit(service.RequestEvent{Source: service.SourceIngresso, URL: url, Cache: service.CacheMemory})
+
+	m := appModel{width: 80, height: 20}
+	var columns []int
+	for _, line := range strings.Split(m.renderRequestLog(), "\n") {
+		plain := ansi.Strip(line)
+		if !strings.Contains(plain, "ingresso.test/ação") {
+			continue
+		}
+		if lipgloss.Width(line) > 80 || !strings.HasSuffix(plain, "…") {
+			t.Fatalf("expected the url cut to the screen width, got %q", plain)
+		}
+		columns = append(columns, lipgloss.Width(plain[:strings.Index(plain, "#")]))
+	}
+	if len(columns) < 2 || columns[len(columns)-1] != columns[len(columns)-2] {
+		t.Fatalf("expected the attempt column aligned after styled results, got %v", columns)
+	}
+}
@@ -0,0 +1,264 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"ingresso-finder-cli/finder"
+	"ingresso-finder-cli/model"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Date picker and week view sizes: how many days the picker offers, how many
+// the week view spans and how wide its theater column may grow. A date range
+// is picked among the picker's days, so it spans at most datePickerDays days
+// from the current date; ctrl+w always shows weekDays days from it.
+const (
+	datePickerDays     = 7
+	weekDays           = 7
+	weekTheaterColumns = 32
+)
+
+type weekMsg struct {
+	gen        int
+	week       []finder.DaySessions
+	failed     int
+	staleSince time.Time
+	err        error
+}
+
+type availableDaysMsg struct {
+	gen  int
+	days map[string]bool
+	err  error
+}
+
+// scheduleTheaters returns the theaters whose schedule is being browsed: the
+// visible ones across theaters, or the selected theater.
+func (m appModel) scheduleTheaters() []model.Theater {
+	if m.browsingAllTheaters {
+		return m.visibleTheaters()
+	}
+	if m.theater.Id != "" {
+		return []model.Theater{m.theater}
+	}
+	return nil
+}
+
+func (m appModel) fetchWeekCmd(theaters []model.Theater, movie model.TheaterMovie, from, to time.Time) tea.Cmd {
+	return func() tea.Msg {
+		ctx, stale := finder.TrackStale(m.requestContext())
+		week, failed, err := m.finder.MovieWeek(ctx, m.city.Id, theaters, movie, from, to, m.userLocation)
+		return weekMsg{gen: m.requestGen, week: week, failed: failed, staleSince: stale.Since(), err: err}
+	}
+}
+
+func (m appModel) fetchAvailableDaysCmd(theater model.Theater) tea.Cmd {
+	return func() tea.Msg {
+		days, err := m.finder.AvailableDays(m.requestContext(), m.city.Id, theater)
+		return availableDaysMsg{gen: m.requestGen, days: days, err: err}
+	}
+}
+
+func (m appModel) fetchMovieDaysCmd(theaters []model.Theater, movie model.TheaterMovie) tea.Cmd {
+	return func() tea.Msg {
+		days, err := m.finder.MovieDays(m.requestContext(), m.city.Id, theaters, movie)
+		return availableDaysMsg{gen: m.requestGen, days: days, err: err}
+	}
+}
+
+// openWeek loads the sessions of the highlighted movie from from to to.
+func (m appModel) openWeek(from, to time.Time) (tea.Model, tea.Cmd, bool) {
+	item, ok := m.movieList.SelectedItem().(movieItem)
+	if !ok {
+		return m, nil, true
+	}
+	theaters := m.scheduleTheaters()
+	if len(theaters) == 0 {
+		return m, errCmd(errors.New("no visible theaters selected")), true
+	}
+	m.weekMovie = item.movie
+	m.week = nil
+	m.weekCursor = 0
+	m.weekFailed = 0
+	m.beginRequests()
+	m.state = stateLoadingWeek
+	return m, tea.Batch(m.fetchWeekCmd(theaters, item.movie, from, to), m.spinner.Tick), true
+}
+
+// selectDateRange opens the week view from the range start marked in the
+// date picker to the chosen day, in either order.
+func (m appModel) selectDateRange(day time.Time) (tea.Model, tea.Cmd, bool) {
+	from, to := m.rangeStart, day
+	if to.Before(from) {
+		from, to = to, from
+	}
+	m.rangeStart = time.Time{}
+	m.dateReturnStateSet = false
+	return m.openWeek(from, to)
+}
+
+// toggleRangeStart marks the highlighted day as the start of a date range, or
+// clears the mark when it is already there.
+func (m appModel) toggleRangeStart() (tea.Model, tea.Cmd, bool) {
+	item, ok := m.dateList.SelectedItem().(dateItem)
+	if !ok {
+		return m, nil, true
+	}
+	if isSameDay(m.rangeStart, item.date) {
+		m.rangeStart = time.Time{}
+	} else {
+		m.rangeStart = item.date
+	}
+	m.dateList.SetItems(buildDateItems(m.date, m.availableDays, m.rangeStart))
+	return m, nil, true
+}
+
+// pickingMovieRange reports whether the date picker was opened over a movie,
+// so a range of days can be searched for it.
+func (m appModel) pickingMovieRange() bool {
+	if !m.dateReturnStateSet {
+		return false
+	}
+	if m.dateReturnState != stateSelectMovie && m.dateReturnState != stateShowSessions {
+		return false
+	}
+	_, ok := m.movieList.SelectedItem().(movieItem)
+	return ok
+}
+
+func (m appModel) moveWeekCursor(step int) (tea.Model, tea.Cmd, bool) {
+	m.weekCursor = min(max(m.weekCursor+step, 0), max(len(m.week)-1, 0))
+	return m, nil, true
+}
+
+// openWeekDay leaves the week view for the movies of the highlighted day.
+func (m appModel) openWeekDay() (tea.Model, tea.Cmd, bool) {
+	if m.weekCursor >= len(m.week) {
+		return m, nil, true
+	}
+	m.date = m.week[m.weekCursor].Date
+	return m.reloadDay()
+}
+
+// weekTheaterRow is one theater's times on a day of the week view.
+type weekTheaterRow struct {
+	theater model.Theater
+	times   []string
+}
+
+func weekTheaterRows(sessions []finder.SessionWithTheater) []weekTheaterRow {
+	var rows []weekTheaterRow
+	index := map[string]int{}
+	for _, session := range sessions {
+		i, ok := index[session.Theater.Id]
+		if !ok {
+			i = len(rows)
+			index[session.Theater.Id] = i
+			rows = append(rows, weekTheaterRow{theater: session.Theater})
+		}
+		rows[i].times = append(rows[i].times, session.Session.Date.LocalDate.Format("15:04"))
+	}
+	return rows
+}
+
+func (m appModel) renderWeek() string {
+	if len(m.week) == 0 {
+		return ""
+	}
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	dayStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("63")).Width(12)
+	activeDayStyle := dayStyle.Bold(true).Foreground(lipgloss.Color("255"))
+	emptyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Faint(true)
+
+	first, last := m.week[0].Date, m.week[len(m.week)-1].Date
+	header := titleStyle.Render(fmt.Sprintf("🗓️  %s • %s a %s", m.weekMovie.Title, first.Format("02/01"), last.Format("02/01"))) + "\n"
+	if m.weekFailed > 0 {
+		header += hint(fmt.Sprintf("⚠️  %d cinemas não puderam ser carregados", m.weekFailed)) + "\n"
+	}
+
+	labelWidth := 0
+	for _, day := range m.week {
+		for _, row := range weekTheaterRows(day.Sessions) {
+			labelWidth = max(labelWidth, lipgloss.Width(m.weekTheaterLabel(row.theater)))
+		}
+	}
+	labelWidth = min(labelWidth, weekTheaterColumns)
+	theaterStyle := lipgloss.NewStyle().Width(labelWidth + 2).MaxWidth(labelWidth + 2)
+
+	var lines []string
+	starts := make([]int, len(m.week))
+	for i, day := range m.week {
+		starts[i] = len(lines)
+		cursor := "  "
+		style := dayStyle
+		if i == m.weekCursor {
+			cursor = "› "
+			style = activeDayStyle
+		}
+		label := day.Date.Format("Mon 02/01")
+		rows := weekTheaterRows(day.Sessions)
+		if len(rows) == 0 {
+			lines = append(lines, cursor+emptyStyle.Render(fmt.Sprintf("%-12s%s", label, "sem sessões")))
+			continue
+		}
+		for j, row := range rows {
+			dayLabel := style.Render(label)
+			if j > 0 {
+				cursor, dayLabel = "  ", style.Render("")
+			}
+			lines = append(lines, cursor+dayLabel+theaterStyle.Render(m.weekTheaterLabel(row.theater))+strings.Join(row.times, "  "))
+		}
+	}
+
+	// Long weeks across many theaters scroll to keep the highlighted day in
+	// view.
+	if m.height > 0 {
+		room := max(m.height-10, 3)
+		if len(lines) > room {
+			offset := min(starts[m.weekCursor], len(lines)-room)
+			lines = lines[offset : offset+room]
+		}
+	}
+	return lipgloss.NewStyle().Padding(0, 2).Render(header + "\n" + strings.Join(lines, "\n"))
+}
+
+// weekTheaterLabel names a theater in the week view, with its city when the
+// week spans a city group.
+func (m appModel) weekTheaterLabel(theater model.Theater) string {
+	if len(m.cityGroup.Cities) > 0 {
+		return theaterCityLabel(theater)
+	}
+	return theater.Name
+}
+
+// dateDelegate greys out the days known to have no sessions.
+type dateDelegate struct {
+	list.DefaultDelegate
+	empty list.DefaultDelegate
+}
+
+func newDateDelegate() dateDelegate {
+	delegate := list.NewDefaultDelegate()
+	empty := list.NewDefaultDelegate()
+	grey := lipgloss.Color("240")
+	empty.Styles.NormalTitle = empty.Styles.NormalTitle.Foreground(grey).Faint(true)
+	empty.Styles.NormalDesc = empty.Styles.NormalDesc.Foreground(grey).Faint(true)
+	empty.Styles.SelectedTitle = empty.Styles.SelectedTitle.Foreground(grey)
+	empty.Styles.SelectedDesc = empty.Styles.SelectedDesc.Foreground(grey)
+	return dateDelegate{DefaultDelegate: delegate, empty: empty}
+}
+
+func (d dateDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if date, ok := item.(dateItem); ok && date.empty {
+		d.empty.Render(w, m, index, item)
+		return
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}